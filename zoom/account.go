@@ -0,0 +1,37 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type accountIDKey struct{}
+
+// WithAccountID returns a context that sends requests made with it to the
+// sub-account accountID, by prefixing the path with /accounts/{accountId}.
+// Without it, requests go to the authenticated account.
+//
+// This is for master accounts managing sub-accounts, and only works for the
+// endpoints Zoom exposes under its Master Account APIs; others return 404. Of
+// the methods in this package, those are:
+//
+//   - Users.List, Users.Create, Users.Delete and GetAllUsers
+//   - Meetings.List and GetAllMeetings
+//   - Phone.Numbers.ListPhoneNumbers and the helpers built on it
+//     (GetExtensionIDByNumber, ListPortingNumbers)
+//
+// Overrides set with WithPathBaseURL match the path without the account
+// prefix, which is added after the overriding base URL.
+func WithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+func accountPath(ctx context.Context, path string) string {
+	accountID, _ := ctx.Value(accountIDKey{}).(string)
+	if len(accountID) == 0 {
+		return path
+	}
+
+	return fmt.Sprintf("/accounts/%s%s", url.PathEscape(accountID), path)
+}
@@ -0,0 +1,55 @@
+package zoom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWithAccountID(t *testing.T) {
+	var lock sync.Mutex
+	var paths []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		paths = append(paths, r.URL.EscapedPath())
+		lock.Unlock()
+
+		w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, handler, WithPathBaseURL("/phone/numbers", srv.URL+"/beta"))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		path string
+		want string
+	}{
+		{"authenticated account", context.Background(), "/users", "/users"},
+		{"sub-account", WithAccountID(context.Background(), "sub"), "/users", "/accounts/sub/users"},
+		{"escaped id", WithAccountID(context.Background(), "a/b"), "/users", "/accounts/a%2Fb/users"},
+		{"with base URL override", WithAccountID(context.Background(), "sub"), "/phone/numbers", "/beta/accounts/sub/phone/numbers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lock.Lock()
+			paths = nil
+			lock.Unlock()
+
+			_, err := c.request(tt.ctx, http.MethodGet, tt.path, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(paths) != 1 || paths[0] != tt.want {
+				t.Errorf("expected %s, got %v", tt.want, paths)
+			}
+		})
+	}
+}
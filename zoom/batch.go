@@ -0,0 +1,38 @@
+package zoom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BatchError collects the failures of a batch operation, keyed by the id of
+// the item that failed. Items missing from Errors succeeded.
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+
+	return fmt.Sprintf("%d failed: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// Unwrap lets errors.Is and errors.As match any of the failures.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
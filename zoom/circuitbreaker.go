@@ -0,0 +1,133 @@
+package zoom
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops sending requests after a number of consecutive
+// failures (transport errors and 5xx responses). Once the cooldown has passed
+// it lets a single probe request through and closes again if it succeeds.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lock     sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a breaker that opens after threshold consecutive
+// failures and stays open for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// WithCircuitBreaker enables the circuit breaker for every request made by the
+// client. It is disabled by default.
+func WithCircuitBreaker(cb *CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.circuitBreaker = cb
+	}
+}
+
+// State returns the current state of the breaker.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	return cb.state
+}
+
+// allow reports whether a request may be sent, and the state transition it
+// caused, if any.
+func (cb *CircuitBreaker) allow() (from, to CircuitState, ok bool) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	from = cb.state
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return from, cb.state, false
+		}
+
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return from, cb.state, true
+	case CircuitHalfOpen:
+		if cb.probing {
+			return from, cb.state, false
+		}
+
+		cb.probing = true
+		return from, cb.state, true
+	default:
+		return from, cb.state, true
+	}
+}
+
+// record reports the outcome of a request allowed through the breaker.
+func (cb *CircuitBreaker) record(success bool) (from, to CircuitState) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	from = cb.state
+	cb.probing = false
+
+	if success {
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return from, cb.state
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+
+	return from, cb.state
+}
+
+// release gives up a request's slot without counting it as a success or a
+// failure, e.g. when the caller cancelled it.
+func (cb *CircuitBreaker) release() {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	cb.probing = false
+}
@@ -0,0 +1,118 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type circuitObserver struct {
+	NopObserver
+
+	lock        sync.Mutex
+	transitions []string
+}
+
+func (o *circuitObserver) CircuitStateChanged(from, to CircuitState) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.transitions = append(o.transitions, from.String()+"->"+to.String())
+}
+
+func TestCircuitBreakerSustainedFailures(t *testing.T) {
+	var hits atomic.Int32
+	var healthy atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if healthy.Load() {
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+	})
+
+	now := time.Now()
+	cb := NewCircuitBreaker(3, time.Minute)
+	cb.now = func() time.Time { return now }
+	obs := &circuitObserver{}
+	c := newTestClient(t, handler, WithCircuitBreaker(cb), WithObserver(obs))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.request(ctx, http.MethodGet, "/users", nil, nil, nil)
+		if err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: expected API error, got %v", i, err)
+		}
+	}
+
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected open circuit, got %s", cb.State())
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := c.request(ctx, http.MethodGet, "/users", nil, nil, nil)
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected ErrCircuitOpen, got %v", err)
+		}
+	}
+
+	if hits.Load() != 3 {
+		t.Fatalf("expected 3 requests to reach the server, got %d", hits.Load())
+	}
+
+	// A failed probe reopens the circuit.
+	now = now.Add(time.Minute)
+	_, err := c.request(ctx, http.MethodGet, "/users", nil, nil, nil)
+	if err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected probe to reach the server, got %v", err)
+	}
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected open circuit after failed probe, got %s", cb.State())
+	}
+
+	// A successful probe closes it.
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	_, err = c.request(ctx, http.MethodGet, "/users", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected closed circuit, got %s", cb.State())
+	}
+
+	want := []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}
+	if len(obs.transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, obs.transitions)
+	}
+	for i := range want {
+		if obs.transitions[i] != want[i] {
+			t.Fatalf("expected transitions %v, got %v", want, obs.transitions)
+		}
+	}
+}
+
+func TestCircuitBreakerResetsOnSuccess(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+
+	cb.record(false)
+	cb.record(true)
+	cb.record(false)
+
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected non-consecutive failures to keep the circuit closed, got %s", cb.State())
+	}
+}
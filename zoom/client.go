@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/TheSlowpes/go-zoom/zoom/tokenmutex"
@@ -19,29 +22,54 @@ import (
 const (
 	zoomAuthURL = "https://zoom.us/oauth/token"
 	zoomBaseURL = "https://api.zoom.us/v2"
+
+	defaultMaxBufferedBodySize = 10 << 20
 )
 
+// Client is safe for concurrent use by multiple goroutines. Its configuration
+// is fixed once NewClient returns; the cached token, circuit breaker and retry
+// jitter are synchronized internally. A custom TokenMutex must hold its lock
+// between Lock and Unlock, since every token access goes through it.
 type Client struct {
 	httpClient   *http.Client
 	accountID    string
 	clientID     string
 	clientSecret string
 	tokenMutex   TokenMutex
+	retryPolicy  *RetryPolicy
+
+	maxBufferedBodySize int64
+	strictDecoding      bool
+	autoPaginate        bool
+	defaultTimeout      time.Duration
+	streamIdleTimeout   time.Duration
+	transportConfig     TransportConfig
+
+	circuitBreaker *CircuitBreaker
+	observer       Observer
+	harRecorder    *HARRecorder
 
-	baseURL string
+	authURL      string
+	baseURL      string
+	pathBaseURLs map[string]string
 
 	Users    *UsersService
 	Meetings *MeetingsService
+	Phone    *PhoneService
 }
 
+// PaginationOptions selects a page of a list endpoint. Most endpoints
+// paginate with NextPageToken; a few older ones use PageNumber instead.
 type PaginationOptions struct {
 	NextPageToken *string `url:"next_page_token,omitempty"`
+	PageNumber    *int    `url:"page_number,omitempty"`
 	PageSize      *int    `url:"page_size,omitempty"`
 }
 
 type PaginationResponse struct {
 	NextPageToken string `json:"next_page_token"`
 	PageCount     int    `json:"page_count"`
+	PageNumber    int    `json:"page_number"`
 	PageSize      int    `json:"page_size"`
 	TotalRecords  int    `json:"total_records"`
 }
@@ -56,9 +84,79 @@ type TokenMutex interface {
 
 var _ TokenMutex = (*tokenmutex.Default)(nil)
 
+// ClientOption configures optional behavior of a Client.
+type ClientOption func(*Client)
+
+// WithMaxBufferedBodySize sets how many bytes of a non-seekable io.Reader
+// request body are buffered so the request can be retried. Larger bodies are
+// streamed and the request is never retried. Defaults to 10 MiB.
+func WithMaxBufferedBodySize(n int64) ClientOption {
+	return func(c *Client) {
+		c.maxBufferedBodySize = n
+	}
+}
+
+// WithStrictDecoding makes the client reject response fields that are not
+// part of the output struct, so tests catch drift between the models and the
+// API. Production code should keep the default lenient decoding.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithDefaultTimeout bounds every request whose context has no deadline to d,
+// including token refreshes and retries. Contexts with a deadline are left
+// alone. d <= 0 disables the default, which is the default.
+func WithDefaultTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = d
+	}
+}
+
+// WithPathBaseURL sends requests whose path starts with prefix, such as
+// "/phone/recordings", to baseURL instead of the stable API base URL, e.g. to
+// use a beta version of an endpoint. The prefix matches whole path segments,
+// and the longest matching prefix wins. There are no overrides by default.
+func WithPathBaseURL(prefix, baseURL string) ClientOption {
+	return func(c *Client) {
+		if c.pathBaseURLs == nil {
+			c.pathBaseURLs = map[string]string{}
+		}
+
+		c.pathBaseURLs[strings.TrimSuffix(prefix, "/")] = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// baseURLFor returns the base URL requests to path are sent to.
+func (c *Client) baseURLFor(path string) string {
+	baseURL, matched := c.baseURL, ""
+	for prefix, override := range c.pathBaseURLs {
+		if len(prefix) <= len(matched) {
+			continue
+		}
+
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			baseURL, matched = override, prefix
+		}
+	}
+
+	return baseURL
+}
+
+func (c *Client) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, c.defaultTimeout)
+}
+
 // NewClient assumes the usage of Server-to-Server OAuth app
 // https://marketplace.zoom.us/docs/guides/build/server-to-server-oauth-app/
-func NewClient(httpClient *http.Client, accountID, clientID, clientSecret string, tokenMutex TokenMutex) *Client {
+// If httpClient is nil, a client with a tuned transport is created (see
+// TransportConfig).
+func NewClient(httpClient *http.Client, accountID, clientID, clientSecret string, tokenMutex TokenMutex, opts ...ClientOption) *Client {
 	if tokenMutex == nil {
 		tokenMutex = tokenmutex.NewDefault()
 	}
@@ -69,31 +167,86 @@ func NewClient(httpClient *http.Client, accountID, clientID, clientSecret string
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		tokenMutex:   tokenMutex,
+		retryPolicy:  DefaultRetryPolicy(),
+		authURL:      zoomAuthURL,
 		baseURL:      zoomBaseURL,
+
+		maxBufferedBodySize: defaultMaxBufferedBodySize,
+		transportConfig:     defaultTransportConfig(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Transport: c.transportConfig.transport()}
+	}
+
+	if c.harRecorder != nil {
+		httpClient := *c.httpClient
+		httpClient.Transport = c.harRecorder.transport(httpClient.Transport)
+		c.httpClient = &httpClient
 	}
 
 	c.Users = &UsersService{c}
 	c.Meetings = &MeetingsService{c}
+	c.Phone = &PhoneService{
+		client:     c,
+		Accounts:   &PhoneAccountsService{c},
+		Alerts:     &PhoneAlertsService{c},
+		Plans:      &PhonePlansService{c},
+		Numbers:    &PhoneNumbersService{c},
+		Recordings: &PhoneRecordingsService{c},
+
+		Reports:          &PhoneReportsService{c},
+		SharedLineGroups: &PhoneSharedLineGroupsService{c},
+	}
 
 	return c
 }
 
+const trackingIDHeader = "x-zm-trackingid"
+
 type ErrorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Errors  []FieldError
+
+	// TrackingID is Zoom's id for the failed request, which Zoom support asks
+	// for.
+	TrackingID string `json:"-"`
 }
 
 func (e *ErrorResponse) Error() string {
 	return e.Message
 }
 
+// TrackingID returns Zoom's tracking id for the request that produced res, or
+// an empty string if res is nil or has none.
+func TrackingID(res *http.Response) string {
+	if res == nil {
+		return ""
+	}
+
+	return res.Header.Get(trackingIDHeader)
+}
+
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
 func (c *Client) request(ctx context.Context, method string, path string, query any, body any, out any) (*http.Response, error) {
+	var watchdog *idleWatchdog
+	var cancel context.CancelFunc
+	if _, ok := out.(io.Writer); ok && c.streamIdleTimeout > 0 {
+		ctx, watchdog, cancel = withIdleWatchdog(ctx, c.streamIdleTimeout)
+	} else {
+		ctx, cancel = c.withDefaultTimeout(ctx)
+	}
+	defer cancel()
+
 	err := c.tokenMutex.Lock(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Error locking token mutex: %w", err)
@@ -142,53 +295,102 @@ func (c *Client) request(ctx context.Context, method string, path string, query
 		return nil, fmt.Errorf("Error encoding query parameters: %w", err)
 	}
 
-	u := fmt.Sprintf("%s%s", c.baseURL, path)
+	u := fmt.Sprintf("%s%s", c.baseURLFor(path), accountPath(ctx, path))
 	if len(q) > 0 {
 		u = fmt.Sprintf("%s?%s", u, q.Encode())
 	}
 
-	reader := bytes.NewReader(nil)
-	if body != nil {
-		b, err := json.Marshal(body)
+	newBody, retryable, err := c.requestBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *http.Response
+	for attempt := 0; ; attempt++ {
+		reader, err := newBody()
 		if err != nil {
-			return nil, fmt.Errorf("Error marshalling request body: %w", err)
+			return nil, fmt.Errorf("Error rewinding request body: %w", err)
 		}
 
-		reader = bytes.NewReader(b)
-	}
+		watchdog.kick()
 
-	req, err := http.NewRequestWithContext(ctx, method, u, reader)
-	if err != nil {
-		return nil, fmt.Errorf("Error making new HTTP request: %w", err)
-	}
+		req, err := http.NewRequestWithContext(ctx, method, u, reader)
+		if err != nil {
+			return nil, fmt.Errorf("Error making new HTTP request: %w", err)
+		}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Content-Type", "application/json")
+		if b, ok := reader.(*seekerBody); ok {
+			req.ContentLength = b.size
+			if b.size == 0 {
+				req.Body = http.NoBody
+			}
+			req.GetBody = func() (io.ReadCloser, error) {
+				r, err := newBody()
+				return io.NopCloser(r), err
+			}
+		}
 
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("Error doing HTTP request: %w", err)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err = c.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("Error doing HTTP request: %w", idleCause(ctx, err))
+		}
+
+		if !retryable || dailyRateLimited(res) || !c.retryPolicy.shouldRetry(ctx, attempt, res.StatusCode) {
+			break
+		}
+
+		delay := c.retryPolicy.retryDelay(attempt, res, time.Now())
+		if !fitsDeadline(ctx, delay) {
+			break
+		}
+
+		res.Body.Close()
+
+		watchdog.pause()
+		c.observeBackoffStarted(delay)
+		err = sleep(ctx, delay)
+		c.observeBackoffFinished()
+		if err != nil {
+			return nil, fmt.Errorf("Error waiting to retry request: %w", idleCause(ctx, err))
+		}
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode > http.StatusIMUsed {
 		if res.StatusCode == http.StatusUnauthorized {
-			err = c.tokenMutex.Clear(ctx)
+			err = c.clearToken(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("error clearing token mutex: %w", err)
+				return nil, err
 			}
 		}
 
-		errRes := &ErrorResponse{}
+		errRes := &ErrorResponse{TrackingID: TrackingID(res)}
 		err = json.NewDecoder(res.Body).Decode(errRes)
 		if err != nil {
 			return res, fmt.Errorf("Error decoding error response body: %w", err)
 		}
 
+		if dailyRateLimited(res) {
+			return res, newDailyRateLimitError(res, errRes, time.Now())
+		}
+
 		return res, fmt.Errorf("%w", errRes)
 	}
 
+	if w, ok := out.(io.Writer); ok {
+		_, err = io.Copy(w, &idleReader{r: res.Body, watchdog: watchdog})
+		if err != nil {
+			return res, fmt.Errorf("Error copying response body: %w", idleCause(ctx, err))
+		}
+
+		return res, nil
+	}
+
 	if out != nil {
-		err = json.NewDecoder(res.Body).Decode(out)
+		err = c.decode(res.Body, out)
 		if err != nil {
 			return res, fmt.Errorf("Error decoding response body: %w", err)
 		}
@@ -197,6 +399,163 @@ func (c *Client) request(ctx context.Context, method string, path string, query
 	return res, nil
 }
 
+// requestBody returns a function producing the body of each attempt of a
+// request, and whether the body can be replayed for a retry. An io.Reader body
+// is sent as-is; seekable readers are rewound between attempts and other
+// readers are buffered up to maxBufferedBodySize. Anything else is encoded
+// with MarshalRequest.
+func (c *Client) requestBody(body any) (func() (io.Reader, error), bool, error) {
+	switch r := body.(type) {
+	case nil:
+		return func() (io.Reader, error) { return bytes.NewReader(nil), nil }, true, nil
+	case io.ReadSeeker:
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, false, fmt.Errorf("Error seeking request body: %w", err)
+		}
+
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, false, fmt.Errorf("Error seeking request body: %w", err)
+		}
+
+		return func() (io.Reader, error) {
+			_, err := r.Seek(offset, io.SeekStart)
+			return &seekerBody{Reader: r, size: end - offset}, err
+		}, true, nil
+	case io.Reader:
+		b, err := io.ReadAll(io.LimitReader(r, c.maxBufferedBodySize+1))
+		if err != nil {
+			return nil, false, fmt.Errorf("Error buffering request body: %w", err)
+		}
+
+		if int64(len(b)) > c.maxBufferedBodySize {
+			return func() (io.Reader, error) { return io.MultiReader(bytes.NewReader(b), r), nil }, false, nil
+		}
+
+		return func() (io.Reader, error) { return bytes.NewReader(b), nil }, true, nil
+	default:
+		b, err := MarshalRequest(body)
+		if err != nil {
+			return nil, false, fmt.Errorf("Error marshalling request body: %w", err)
+		}
+
+		return func() (io.Reader, error) { return bytes.NewReader(b), nil }, true, nil
+	}
+}
+
+// seekerBody is a rewound seekable request body. It hides the seeker's
+// concrete type, so the transport does not close an io.Closer such as an
+// *os.File after each attempt, and carries the length that the transport can
+// no longer infer.
+type seekerBody struct {
+	io.Reader
+	size int64
+}
+
+// Do sends a raw request to path, relative to the API base URL, for endpoints
+// without a typed method yet. It shares the auth, retry, circuit breaker and
+// error handling of the typed methods: query is encoded with its url tags,
+// body as for any request, and a JSON response is decoded into out unless out
+// is nil or an io.Writer, which receives the raw body. Its behavior for a
+// given endpoint is not covered by compatibility guarantees; prefer the typed
+// method once one exists.
+func (c *Client) Do(ctx context.Context, method string, path string, query any, body any, out any) (*http.Response, error) {
+	return c.request(ctx, method, path, query, body, out)
+}
+
+// Ping makes a minimal phone account settings read to confirm the credentials
+// and network path work, e.g. for readiness probes. It is a real API call and
+// counts against the rate limit. API errors can be inspected with errors.As
+// and *ErrorResponse.
+func (c *Client) Ping(ctx context.Context) error {
+	query := NewAccountSettingsQuery("outbound_calling")
+
+	_, err := c.request(ctx, http.MethodGet, "/phone/account_settings", query, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Error pinging Zoom: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalRequest encodes v exactly as the client encodes request bodies on the
+// wire, including omitempty handling. It is meant for golden tests of request
+// payloads.
+func MarshalRequest(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// DecodeStrict decodes JSON from r into v, failing on unknown fields.
+func DecodeStrict(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+func (c *Client) decode(r io.Reader, v any) error {
+	if c.strictDecoding {
+		return DecodeStrict(r, v)
+	}
+
+	return json.NewDecoder(r).Decode(v)
+}
+
+// do sends req through the circuit breaker, if one is configured.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.circuitBreaker == nil {
+		return c.send(req)
+	}
+
+	from, to, ok := c.circuitBreaker.allow()
+	c.observeCircuit(from, to)
+	if !ok {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err := c.send(req)
+	if errors.Is(err, context.Canceled) {
+		c.circuitBreaker.release()
+		return res, err
+	}
+
+	from, to = c.circuitBreaker.record(err == nil && res.StatusCode < http.StatusInternalServerError)
+	c.observeCircuit(from, to)
+
+	return res, err
+}
+
+// send makes one HTTP attempt, notifying the observer around it.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	c.observeRequestStarted()
+	defer c.observeRequestFinished()
+
+	return c.httpClient.Do(req)
+}
+
+// clearToken drops the cached token, holding the token mutex so it cannot
+// race with a concurrent refresh.
+func (c *Client) clearToken(ctx context.Context) error {
+	err := c.tokenMutex.Lock(ctx)
+	if err != nil {
+		return fmt.Errorf("Error locking token mutex: %w", err)
+	}
+
+	err = c.tokenMutex.Clear(ctx)
+	if err != nil {
+		_ = c.tokenMutex.Unlock(ctx)
+		return fmt.Errorf("Error clearing token mutex: %w", err)
+	}
+
+	err = c.tokenMutex.Unlock(ctx)
+	if err != nil {
+		return fmt.Errorf("Error unlocking token mutex: %w", err)
+	}
+
+	return nil
+}
+
 type authResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
@@ -205,13 +564,26 @@ type authResponse struct {
 }
 
 func (c *Client) accessToken(ctx context.Context) (string, time.Time, error) {
+	authRes, err := c.authenticate(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// Add a buffer to the expiration.
+	expiresIn := authRes.ExpiresIn - 300
+
+	return authRes.AccessToken, time.Now().Add(time.Duration(expiresIn) * time.Second), nil
+}
+
+// authenticate requests a new access token with the account credentials.
+func (c *Client) authenticate(ctx context.Context) (*authResponse, error) {
 	query := url.Values{}
 	query.Set("grant_type", "account_credentials")
 	query.Set("account_id", c.accountID)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s?%s", zoomAuthURL, query.Encode()), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s?%s", c.authURL, query.Encode()), nil)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("Error creating HTTP request: %w", err)
+		return nil, fmt.Errorf("Error creating HTTP request: %w", err)
 	}
 
 	auth := base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", c.clientID, c.clientSecret)))
@@ -219,23 +591,55 @@ func (c *Client) accessToken(ctx context.Context) (string, time.Time, error) {
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("Error doing HTTP request: %w", err)
+		return nil, fmt.Errorf("Error doing HTTP request: %w", err)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return "", time.Time{}, fmt.Errorf("received non-200 status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("received non-200 status code: %d", res.StatusCode)
 	}
 
 	authRes := &authResponse{}
 	err = json.NewDecoder(res.Body).Decode(authRes)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("Error decoding response body: %w", err)
+		return nil, fmt.Errorf("Error decoding response body: %w", err)
 	}
 
-	// Add a buffer to the expiration.
-	expiresIn := authRes.ExpiresIn - 300
+	return authRes, nil
+}
 
-	return authRes.AccessToken, time.Now().Add(time.Duration(expiresIn) * time.Second), nil
+// MissingScopesError lists the OAuth scopes an app needs but was not granted.
+type MissingScopesError struct {
+	Missing []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("app is missing OAuth scopes: %s", strings.Join(e.Missing, ", "))
+}
+
+// CheckScopes requests a token with the client's credentials and returns a
+// *MissingScopesError listing the required scopes it was not granted, e.g. to
+// fail fast at startup. The cached token is left untouched.
+func (c *Client) CheckScopes(ctx context.Context, required ...string) error {
+	authRes, err := c.authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf("Error getting access token: %w", err)
+	}
+
+	granted := strings.Fields(authRes.Scope)
+
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingScopesError{Missing: missing}
+	}
+
+	return nil
 }
 
 // MeetingSDKJWT creates a Meeting SDK JWT, signs it, and returns the signed string (see https://marketplace.zoom.us/docs/sdk/native-sdks/auth/#meeting-sdk-auth).
@@ -0,0 +1,398 @@
+package zoom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TheSlowpes/go-zoom/zoom/tokenmutex"
+)
+
+// newTestClient returns a client with a valid cached token that sends every
+// request to handler.
+func newTestClient(t *testing.T, handler http.Handler, opts ...ClientOption) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	tm := tokenmutex.NewDefault()
+	err := tm.Set(context.Background(), "token", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(srv.Client(), "account", "client", "secret", tm, opts...)
+	c.baseURL = srv.URL
+
+	return c
+}
+
+// streamReader hides any Seek method of the wrapped reader.
+type streamReader struct {
+	r io.Reader
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	return s.r.Read(p)
+}
+
+func retryingPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:  2,
+		StatusCodes: []int{http.StatusServiceUnavailable},
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+}
+
+func TestRequestRetriesStreamedBody(t *testing.T) {
+	const payload = "streamed audio upload"
+
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, handler, WithRetryPolicy(retryingPolicy()))
+
+	_, err := c.request(context.Background(), http.MethodPost, "/upload", nil, &streamReader{strings.NewReader(payload)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != payload {
+			t.Fatalf("attempt %d: expected body %q, got %q", i, payload, body)
+		}
+	}
+}
+
+func TestRequestRetriesFileBody(t *testing.T) {
+	const payload = "audio file upload"
+
+	name := filepath.Join(t.TempDir(), "upload.mp3")
+	err := os.WriteFile(name, []byte(payload), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, handler, WithRetryPolicy(retryingPolicy()))
+
+	_, err = c.request(context.Background(), http.MethodPost, "/upload", nil, f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != payload || bodies[1] != payload {
+		t.Fatalf("expected 2 attempts with body %q, got %q", payload, bodies)
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		t.Fatalf("expected the file to be left open, got %v", err)
+	}
+}
+
+func TestRequestSeekableBodyContentLength(t *testing.T) {
+	var lengths []int64
+	var encodings [][]string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lengths = append(lengths, r.ContentLength)
+		encodings = append(encodings, r.TransferEncoding)
+		w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	body := bytes.NewReader([]byte("0123456789"))
+	_, err := c.request(context.Background(), http.MethodPost, "/upload", nil, body, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partial := strings.NewReader("0123456789")
+	partial.Seek(4, io.SeekStart)
+	_, err = c.request(context.Background(), http.MethodPost, "/upload", nil, partial, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(lengths) != "[10 6]" || len(encodings[0]) != 0 || len(encodings[1]) != 0 {
+		t.Errorf("expected Content-Length 10 and 6 without chunking, got %v and %v", lengths, encodings)
+	}
+}
+
+func TestRequestDoesNotRetryOversizedStreamedBody(t *testing.T) {
+	const payload = "streamed audio upload"
+
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+	})
+
+	c := newTestClient(t, handler, WithRetryPolicy(retryingPolicy()), WithMaxBufferedBodySize(4))
+
+	_, err := c.request(context.Background(), http.MethodPost, "/upload", nil, &streamReader{strings.NewReader(payload)}, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 attempt, got %d", len(bodies))
+	}
+	if bodies[0] != payload {
+		t.Fatalf("expected body %q, got %q", payload, bodies[0])
+	}
+}
+
+// TestClientConcurrentUse hammers a shared client while tokens are being
+// refreshed; run with -race to check for data races.
+func TestClientConcurrentUse(t *testing.T) {
+	var tokens, calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, tokens.Add(1))
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1)%5 == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":124,"message":"Invalid access token."}`))
+			return
+		}
+
+		w.Write([]byte(`{"users":[]}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.Client(), "account", "client", "secret", nil, WithCircuitBreaker(NewCircuitBreaker(1000, time.Second)))
+	c.authURL = srv.URL + "/oauth/token"
+	c.baseURL = srv.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 25; j++ {
+				_, _, err := c.Users.List(context.Background(), nil)
+				var errRes *ErrorResponse
+				if err != nil && !errors.As(err, &errRes) {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tokens.Load() < 2 {
+		t.Fatalf("expected the token to be refreshed, got %d token requests", tokens.Load())
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, handler, WithDefaultTimeout(20*time.Millisecond))
+
+	t.Run("applies default", func(t *testing.T) {
+		_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("inherits existing deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := c.request(ctx, http.MethodGet, "/users", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("expected the caller's deadline to be used, got %v", err)
+		}
+	})
+}
+
+func TestDo(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/phone/new_endpoint" || r.URL.Query().Get("page_size") != "10" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": 404, "message": "Not found."}`))
+			return
+		}
+
+		w.Write([]byte(`{"name": "value"}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	pageSize := 10
+	var out struct {
+		Name string `json:"name"`
+	}
+	_, err := c.Do(context.Background(), http.MethodGet, "/phone/new_endpoint", &PaginationOptions{PageSize: &pageSize}, nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "value" {
+		t.Errorf("expected the response to be decoded, got %q", out.Name)
+	}
+
+	_, err = c.Do(context.Background(), http.MethodGet, "/phone/missing", nil, nil, nil)
+	var errRes *ErrorResponse
+	if !errors.As(err, &errRes) || errRes.Code != 404 {
+		t.Errorf("expected an *ErrorResponse with code 404, got %v", err)
+	}
+}
+
+func TestPathBaseURL(t *testing.T) {
+	var lock sync.Mutex
+	var paths []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		paths = append(paths, r.URL.Path)
+		lock.Unlock()
+
+		w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, handler,
+		WithPathBaseURL("/phone/recordings", srv.URL+"/beta/"),
+		WithPathBaseURL("/phone", srv.URL+"/v3"),
+	)
+
+	for _, path := range []string{"/phone/recordings", "/phone/recordings/abc", "/phone/recordings_archive", "/users"} {
+		_, err := c.request(context.Background(), http.MethodGet, path, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"/beta/phone/recordings", "/beta/phone/recordings/abc", "/v3/phone/recordings_archive", "/users"}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("expected paths %v, got %v", want, paths)
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": 3600, "scope": "phone:read:admin user:read:admin"}`))
+	})
+
+	c := newTestClient(t, handler)
+	c.authURL = c.baseURL + "/oauth/token"
+
+	err := c.CheckScopes(context.Background(), "phone:read:admin", "user:read:admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.CheckScopes(context.Background(), "phone:read:admin", "phone:write:admin", "meeting:read:admin")
+	var scopesErr *MissingScopesError
+	if !errors.As(err, &scopesErr) {
+		t.Fatalf("expected a *MissingScopesError, got %v", err)
+	}
+	if fmt.Sprint(scopesErr.Missing) != "[phone:write:admin meeting:read:admin]" {
+		t.Errorf("unexpected missing scopes %v", scopesErr.Missing)
+	}
+}
+
+func TestDailyRateLimit(t *testing.T) {
+	for _, format := range []string{http.TimeFormat, time.RFC3339} {
+		t.Run(format, func(t *testing.T) {
+			testDailyRateLimit(t, format)
+		})
+	}
+}
+
+func testDailyRateLimit(t *testing.T, format string) {
+	resetAt := time.Now().Add(6 * time.Hour).UTC().Truncate(time.Second)
+
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		w.Header().Set("X-RateLimit-Type", "Daily-limit")
+		w.Header().Set("Retry-After", resetAt.Format(format))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code": 429, "message": "You have reached the maximum daily rate limit for this API."}`))
+	})
+
+	c := newTestClient(t, handler, WithRetryPolicy(&RetryPolicy{MaxRetries: 3, StatusCodes: []int{http.StatusTooManyRequests}}))
+
+	_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+	if !errors.Is(err, ErrDailyRateLimitExceeded) {
+		t.Fatalf("expected ErrDailyRateLimitExceeded, got %v", err)
+	}
+
+	var limitErr *DailyRateLimitError
+	if !errors.As(err, &limitErr) || !limitErr.ResetAt.Equal(resetAt) {
+		t.Errorf("expected the reset time %s, got %v", resetAt, err)
+	}
+
+	var errRes *ErrorResponse
+	if !errors.As(err, &errRes) || errRes.Code != 429 {
+		t.Errorf("expected the *ErrorResponse to be wrapped, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the daily limit not to be retried, got %d calls", calls.Load())
+	}
+}
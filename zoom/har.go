@@ -0,0 +1,375 @@
+package zoom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const harRedacted = "[REDACTED]"
+
+// HARRecorder records every request made by a client, and its response, as an
+// HTTP Archive (HAR 1.2) log, e.g. to attach to a Zoom support ticket. The
+// Authorization header and OAuth token bodies are redacted. A response is
+// recorded once its body has been read to the end or closed, with the part
+// that was read. Bodies are copied as they are sent and received, and only
+// their first MiB is kept in memory until Close.
+//
+// A HARRecorder is safe for concurrent use. Nothing is written until Close.
+type HARRecorder struct {
+	w io.Writer
+
+	lock    sync.Mutex
+	entries []harEntry
+	closed  bool
+}
+
+// NewHARRecorder returns a recorder writing its log to w on Close.
+func NewHARRecorder(w io.Writer) *HARRecorder {
+	return &HARRecorder{w: w}
+}
+
+// WithHARRecorder records the client's HTTP traffic, including token requests,
+// with r. The client's http.Client is copied rather than modified.
+func WithHARRecorder(r *HARRecorder) ClientOption {
+	return func(c *Client) {
+		c.harRecorder = r
+	}
+}
+
+// Close writes the log to the recorder's writer. Requests finishing after
+// Close are not recorded, and later calls to Close do nothing.
+func (r *HARRecorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	log := harLog{
+		Log: harLogBody{
+			Version: "1.2",
+			Creator: harCreator{Name: "go-zoom", Version: "1"},
+			Entries: r.entries,
+		},
+	}
+	if log.Log.Entries == nil {
+		log.Log.Entries = []harEntry{}
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(log)
+	if err != nil {
+		return fmt.Errorf("Error writing HAR log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *HARRecorder) record(e harEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.closed {
+		r.entries = append(r.entries, e)
+	}
+}
+
+// transport returns a RoundTripper recording the traffic sent through next,
+// or http.DefaultTransport if next is nil.
+func (r *HARRecorder) transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &harTransport{recorder: r, next: next}
+}
+
+type harTransport struct {
+	recorder *HARRecorder
+	next     http.RoundTripper
+}
+
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	redact := strings.HasSuffix(req.URL.Path, "/oauth/token")
+
+	var reqBody *harCapture
+	if req.Body != nil && req.Body != http.NoBody {
+		reqBody = &harCapture{}
+		req = teeRequest(req, reqBody)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody := &harCapture{}
+	res.Body = &harBody{
+		ReadCloser: res.Body,
+		capture:    resBody,
+		finish: func() {
+			request, response := newHARRequest(req, reqBody), newHARResponse(res, resBody)
+			if redact {
+				if request.PostData != nil {
+					request.PostData.Text = harRedacted
+				}
+				response.Content.Text = harRedacted
+			}
+
+			elapsed := float64(time.Since(started)) / float64(time.Millisecond)
+			t.recorder.record(harEntry{
+				StartedDateTime: started.Format(time.RFC3339Nano),
+				Time:            elapsed,
+				Request:         request,
+				Response:        response,
+				Cache:           struct{}{},
+				Timings:         harTimings{Send: 0, Wait: elapsed, Receive: 0},
+			})
+		},
+	}
+
+	return res, nil
+}
+
+// teeRequest returns a shallow copy of req whose body, including any body
+// replayed through GetBody, is copied into c as the transport sends it.
+func teeRequest(req *http.Request, c *harCapture) *http.Request {
+	tee := new(http.Request)
+	*tee = *req
+	tee.Body = &harBody{ReadCloser: req.Body, capture: c, finish: func() {}}
+
+	if req.GetBody != nil {
+		tee.GetBody = func() (io.ReadCloser, error) {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			c.reset()
+			return &harBody{ReadCloser: body, capture: c, finish: func() {}}, nil
+		}
+	}
+
+	return tee
+}
+
+// harMaxBodySize is how much of each request and response body is recorded,
+// so large uploads and downloads are not held in memory until Close.
+const harMaxBodySize = 1 << 20
+
+// harCapture keeps the first harMaxBodySize bytes written to it, and counts
+// the rest. It is safe for concurrent use, as the transport may still be
+// sending a request body when the response arrives.
+type harCapture struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+	size int
+}
+
+func (c *harCapture) Write(p []byte) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.size += len(p)
+	if room := harMaxBodySize - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(len(p), room)])
+	}
+
+	return len(p), nil
+}
+
+func (c *harCapture) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.buf.Reset()
+	c.size = 0
+}
+
+// content returns the recorded text, the full size of the body, and whether
+// the text was truncated.
+func (c *harCapture) content() (string, int, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.buf.String(), c.size, c.size > c.buf.Len()
+}
+
+// harBody copies a body into its capture as it is read, and calls finish once
+// the body hits EOF or is closed, so streaming is not held up.
+type harBody struct {
+	io.ReadCloser
+
+	capture *harCapture
+	once    sync.Once
+	finish  func()
+}
+
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture.Write(p[:n])
+	if err == io.EOF {
+		b.once.Do(b.finish)
+	}
+
+	return n, err
+}
+
+func (b *harBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.finish)
+
+	return err
+}
+
+func harComment(truncated bool) string {
+	if truncated {
+		return fmt.Sprintf("truncated to %d bytes", harMaxBodySize)
+	}
+
+	return ""
+}
+
+func newHARRequest(req *http.Request, body *harCapture) harRequest {
+	r := harRequest{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Headers:     harHeaders(req.Header),
+		QueryString: []harNameValue{},
+		Cookies:     []harNameValue{},
+		HeadersSize: -1,
+	}
+
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			r.QueryString = append(r.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	if body != nil {
+		text, size, truncated := body.content()
+		r.BodySize = size
+		r.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: text, Comment: harComment(truncated)}
+	}
+
+	return r
+}
+
+func newHARResponse(res *http.Response, body *harCapture) harResponse {
+	text, size, truncated := body.content()
+
+	return harResponse{
+		Status:      res.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(res.Status, fmt.Sprint(res.StatusCode))),
+		HTTPVersion: res.Proto,
+		Headers:     harHeaders(res.Header),
+		Cookies:     []harNameValue{},
+		Content: harContent{
+			Size:     size,
+			MimeType: res.Header.Get("Content-Type"),
+			Text:     text,
+			Comment:  harComment(truncated),
+		},
+		HeadersSize: -1,
+		BodySize:    size,
+	}
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range h {
+		for _, value := range values {
+			if http.CanonicalHeaderKey(name) == "Authorization" {
+				value = harRedacted
+			}
+
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+type harLog struct {
+	Log harLogBody `json:"log"`
+}
+
+type harLogBody struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,207 @@
+package zoom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHARRecorder(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token": "secret-token", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"users": []}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	var buf bytes.Buffer
+	recorder := NewHARRecorder(&buf)
+
+	c := NewClient(srv.Client(), "account", "client", "secret", nil, WithHARRecorder(recorder))
+	c.authURL = srv.URL + "/oauth/token"
+	c.baseURL = srv.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, _, err := c.Users.List(context.Background(), nil)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	err := recorder.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Error("expected the access token to be redacted")
+	}
+
+	var har harLog
+	err = json.Unmarshal(buf.Bytes(), &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 5 {
+		t.Fatalf("expected a HAR 1.2 log with 5 entries, got version %q with %d", har.Log.Version, len(har.Log.Entries))
+	}
+
+	for _, e := range har.Log.Entries {
+		for _, h := range e.Request.Headers {
+			if h.Name == "Authorization" && h.Value != harRedacted {
+				t.Errorf("expected the Authorization header to be redacted, got %q", h.Value)
+			}
+		}
+
+		if strings.HasSuffix(e.Request.URL, "/users") && e.Response.Content.Text != `{"users": []}` {
+			t.Errorf("expected the response body to be recorded, got %q", e.Response.Content.Text)
+		}
+	}
+}
+
+func TestHARRecorderStreamsDownloads(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewHARRecorder(&buf)
+
+	c := newTestClient(t, throttledHandler(10, 20*time.Millisecond, 0), WithHARRecorder(recorder), WithStreamIdleTimeout(100*time.Millisecond))
+
+	var out bytes.Buffer
+	_, err := c.Phone.Recordings.DownloadRecording(context.Background(), "id", &out)
+	if err != nil {
+		t.Fatalf("expected the recorder not to hold up a progressing download, got %v", err)
+	}
+
+	err = recorder.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har harLog
+	err = json.Unmarshal(buf.Bytes(), &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(har.Log.Entries) != 1 || har.Log.Entries[0].Response.Content.Text != out.String() {
+		t.Errorf("expected the download to be recorded, got %+v", har.Log.Entries)
+	}
+}
+
+func TestHARRecorderStreamsUploads(t *testing.T) {
+	received := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first := make([]byte, len("first"))
+		_, err := io.ReadFull(r.Body, first)
+		if err != nil {
+			t.Error(err)
+		}
+		close(received)
+
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{}`))
+	})
+
+	var buf bytes.Buffer
+	recorder := NewHARRecorder(&buf)
+
+	c := newTestClient(t, handler, WithHARRecorder(recorder), WithMaxBufferedBodySize(1))
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("first"))
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			pw.CloseWithError(errors.New("upload was buffered before being sent"))
+			return
+		}
+
+		pw.Write([]byte("second"))
+		pw.Close()
+	}()
+
+	_, err := c.Do(context.Background(), http.MethodPost, "/upload", nil, pr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = recorder.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har harLog
+	err = json.Unmarshal(buf.Bytes(), &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(har.Log.Entries) != 1 || har.Log.Entries[0].Request.PostData == nil || har.Log.Entries[0].Request.PostData.Text != "firstsecond" {
+		t.Errorf("expected the upload to be recorded, got %+v", har.Log.Entries)
+	}
+}
+
+func TestHARRecorderTruncatesBodies(t *testing.T) {
+	body := strings.Repeat("x", harMaxBodySize+10)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(body))
+	})
+
+	var buf bytes.Buffer
+	recorder := NewHARRecorder(&buf)
+
+	c := newTestClient(t, handler, WithHARRecorder(recorder))
+
+	var out bytes.Buffer
+	_, err := c.Do(context.Background(), http.MethodPost, "/upload", nil, strings.NewReader(body), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != len(body) {
+		t.Fatalf("expected the whole body to be returned, got %d bytes", out.Len())
+	}
+
+	err = recorder.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har harLog
+	err = json.Unmarshal(buf.Bytes(), &har)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(har.Log.Entries))
+	}
+
+	req, res := har.Log.Entries[0].Request, har.Log.Entries[0].Response
+	if len(req.PostData.Text) != harMaxBodySize || req.BodySize != len(body) || req.PostData.Comment == "" {
+		t.Errorf("expected the request body to be truncated, got %d of %d bytes", len(req.PostData.Text), req.BodySize)
+	}
+	if len(res.Content.Text) != harMaxBodySize || res.Content.Size != len(body) || res.Content.Comment == "" {
+		t.Errorf("expected the response body to be truncated, got %d of %d bytes", len(res.Content.Text), res.Content.Size)
+	}
+}
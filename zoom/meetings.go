@@ -55,12 +55,44 @@ func (m *MeetingsService) List(ctx context.Context, userID string, opts *Meeting
 
 	res, err := m.client.request(ctx, http.MethodGet, fmt.Sprintf("/users/%s/meetings", url.QueryEscape(userID)), opts, nil, out)
 	if err != nil {
-		return nil, nil, errs.Wrap(err, "making HTTP request")
+		return nil, nil, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if m.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.Meetings, m.pages(userID, opts))
+		if err != nil {
+			return nil, res, err
+		}
 	}
 
 	return out, res, nil
 }
 
+// GetAllMeetings follows next_page_token until every meeting matching opts has
+// been fetched. If limit is greater than zero, at most limit meetings are
+// returned.
+func (m *MeetingsService) GetAllMeetings(ctx context.Context, userID string, opts *MeetingsListOptions, limit int) ([]*MeetingsListItem, error) {
+	return collectAll(ctx, limit, m.pages(userID, opts))
+}
+
+func (m *MeetingsService) pages(userID string, opts *MeetingsListOptions) pageFunc[*MeetingsListItem] {
+	o := MeetingsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*MeetingsListItem, *PaginationResponse, error) {
+		o.PaginationOptions = withPage(o.PaginationOptions, cursor)
+
+		out, _, err := m.List(ctx, userID, &o)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.Meetings, out.PaginationResponse, nil
+	}
+}
+
 type MeetingsCreateOptions struct {
 	DefaultPassword *bool                           `json:"default_password,omitempty"`
 	Duration        *int                            `json:"duration,omitempty"`
@@ -228,7 +260,7 @@ func (m *MeetingsService) Create(ctx context.Context, userID string, opts *Meeti
 
 	res, err := m.client.request(ctx, http.MethodPost, fmt.Sprintf("/users/%s/meetings", url.QueryEscape(userID)), nil, opts, out)
 	if err != nil {
-		return nil, res, errs.Wrap(err, "making HTTP request")
+		return nil, res, fmt.Errorf("Error making request: %w", err)
 	}
 
 	return out, res, nil
@@ -246,7 +278,7 @@ func (m *MeetingsService) Delete(ctx context.Context, meetingID int64, opts *Mee
 
 	res, err := m.client.request(ctx, http.MethodDelete, fmt.Sprintf("/meetings/%s", url.QueryEscape(mID)), opts, nil, nil)
 	if err != nil {
-		return res, errs.Wrap(err, "making request")
+		return res, fmt.Errorf("Error making request: %w", err)
 	}
 
 	return res, nil
@@ -0,0 +1,72 @@
+package zoom
+
+import "time"
+
+// Observer receives notifications about the client's internal state, e.g. to
+// export metrics. Implementations must be safe for concurrent use. Embed
+// NopObserver to implement only some of the methods.
+//
+// Timings are best-effort: callbacks run synchronously around the request and
+// do not account for time spent in the transport's connection pool.
+type Observer interface {
+	// CircuitStateChanged is called when the circuit breaker changes state.
+	CircuitStateChanged(from, to CircuitState)
+	// RequestStarted and RequestFinished bracket every HTTP attempt,
+	// including retries, so their difference is the number in flight.
+	// Attempts rejected by an open circuit breaker never reach the network
+	// and are not reported.
+	RequestStarted()
+	RequestFinished()
+	// BackoffStarted and BackoffFinished bracket the time a request sleeps
+	// before being retried.
+	BackoffStarted(delay time.Duration)
+	BackoffFinished()
+}
+
+// NopObserver implements Observer with methods that do nothing.
+type NopObserver struct{}
+
+var _ Observer = NopObserver{}
+
+func (NopObserver) CircuitStateChanged(from, to CircuitState) {}
+func (NopObserver) RequestStarted()                           {}
+func (NopObserver) RequestFinished()                          {}
+func (NopObserver) BackoffStarted(delay time.Duration)        {}
+func (NopObserver) BackoffFinished()                          {}
+
+// WithObserver sets the observer notified by the client.
+func WithObserver(o Observer) ClientOption {
+	return func(c *Client) {
+		c.observer = o
+	}
+}
+
+func (c *Client) observeCircuit(from, to CircuitState) {
+	if c.observer != nil && from != to {
+		c.observer.CircuitStateChanged(from, to)
+	}
+}
+
+func (c *Client) observeRequestStarted() {
+	if c.observer != nil {
+		c.observer.RequestStarted()
+	}
+}
+
+func (c *Client) observeRequestFinished() {
+	if c.observer != nil {
+		c.observer.RequestFinished()
+	}
+}
+
+func (c *Client) observeBackoffStarted(delay time.Duration) {
+	if c.observer != nil {
+		c.observer.BackoffStarted(delay)
+	}
+}
+
+func (c *Client) observeBackoffFinished() {
+	if c.observer != nil {
+		c.observer.BackoffFinished()
+	}
+}
@@ -0,0 +1,83 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type eventObserver struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (o *eventObserver) add(event string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.events = append(o.events, event)
+}
+
+func (o *eventObserver) CircuitStateChanged(from, to CircuitState) {
+	o.add(from.String() + "->" + to.String())
+}
+func (o *eventObserver) RequestStarted()                    { o.add("started") }
+func (o *eventObserver) RequestFinished()                   { o.add("finished") }
+func (o *eventObserver) BackoffStarted(delay time.Duration) { o.add("backoff " + delay.String()) }
+func (o *eventObserver) BackoffFinished()                   { o.add("backoff done") }
+
+func TestObserverBracketsAttempts(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	o := &eventObserver{}
+	c := newTestClient(t, handler, WithRetryPolicy(retryingPolicy()), WithObserver(o))
+
+	_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[started finished backoff 1ms backoff done started finished]"
+	if fmt.Sprint(o.events) != want {
+		t.Errorf("expected events %s, got %v", want, o.events)
+	}
+}
+
+func TestObserverSkipsOpenCircuit(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":503,"message":"unavailable"}`))
+	})
+
+	o := &eventObserver{}
+	c := newTestClient(t, handler, WithCircuitBreaker(NewCircuitBreaker(1, time.Minute)), WithObserver(o))
+
+	_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	_, err = c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	want := fmt.Sprintf("[started finished %s->%s]", CircuitClosed, CircuitOpen)
+	if fmt.Sprint(o.events) != want {
+		t.Errorf("expected events %s, got %v", want, o.events)
+	}
+}
@@ -0,0 +1,148 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+)
+
+// pageFunc fetches the page at cursor, which only has NextPageToken or
+// PageNumber set, and neither for the first page.
+type pageFunc[T any] func(ctx context.Context, cursor *PaginationOptions) ([]T, *PaginationResponse, error)
+
+// collectAll calls fetch until the last page and returns every item. It
+// follows next_page_token, or for endpoints that paginate by page number,
+// increments page_number until page_count is reached. If limit is greater
+// than zero, at most limit items are returned. The context is checked before
+// each page.
+func collectAll[T any](ctx context.Context, limit int, fetch pageFunc[T]) ([]T, error) {
+	var items []T
+
+	ctx = singlePage(ctx)
+
+	cursor := &PaginationOptions{}
+	tokenPaginated := false
+	for {
+		err := ctx.Err()
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching next page: %w", err)
+		}
+
+		page, pagination, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+
+		if pagination == nil {
+			return items, nil
+		}
+
+		cursor, tokenPaginated = nextPage(pagination, tokenPaginated)
+		if cursor == nil {
+			return items, nil
+		}
+	}
+}
+
+// fetchRemaining fetches the pages after the one described by pagination,
+// appending their items to items, and clears its next page token. It is used
+// by List methods when auto-pagination is enabled.
+func fetchRemaining[T any](ctx context.Context, pagination *PaginationResponse, items *[]T, fetch pageFunc[T]) error {
+	if pagination == nil {
+		return nil
+	}
+
+	ctx = singlePage(ctx)
+
+	cursor, tokenPaginated := nextPage(pagination, false)
+	for cursor != nil {
+		err := ctx.Err()
+		if err != nil {
+			return fmt.Errorf("Error fetching next page: %w", err)
+		}
+
+		page, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return err
+		}
+
+		*items = append(*items, page...)
+		if next == nil {
+			break
+		}
+
+		cursor, tokenPaginated = nextPage(next, tokenPaginated)
+	}
+
+	pagination.NextPageToken = ""
+	return nil
+}
+
+type singlePageKey struct{}
+
+// singlePage marks ctx so List methods called with it return a single page
+// even when auto-pagination is enabled, since the caller is paginating.
+func singlePage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, singlePageKey{}, true)
+}
+
+// WithAutoPaginate makes List methods follow pagination and return every
+// record in the response's slice, with an empty next_page_token. The whole
+// result set is held in memory. page_size still sets how many records each
+// underlying request fetches, so a larger page size means fewer requests.
+func WithAutoPaginate() ClientOption {
+	return func(c *Client) {
+		c.autoPaginate = true
+	}
+}
+
+func (c *Client) autoPaginating(ctx context.Context) bool {
+	return c.autoPaginate && ctx.Value(singlePageKey{}) == nil
+}
+
+// nextPage returns the cursor of the page after the one described by
+// pagination, or nil if it was the last one. Once an endpoint has returned a
+// next_page_token it is only paginated by token, since token endpoints may
+// also report page_number and page_count.
+func nextPage(pagination *PaginationResponse, tokenPaginated bool) (*PaginationOptions, bool) {
+	if len(pagination.NextPageToken) > 0 {
+		nextPageToken := pagination.NextPageToken
+		return &PaginationOptions{NextPageToken: &nextPageToken}, true
+	}
+
+	if tokenPaginated {
+		return nil, true
+	}
+
+	if pagination.PageNumber == 0 || pagination.PageNumber >= pagination.PageCount {
+		return nil, false
+	}
+
+	pageNumber := pagination.PageNumber + 1
+	return &PaginationOptions{PageNumber: &pageNumber}, false
+}
+
+// withPage returns a copy of opts positioned at cursor, leaving the caller's
+// options untouched.
+func withPage(opts *PaginationOptions, cursor *PaginationOptions) *PaginationOptions {
+	out := &PaginationOptions{}
+	if opts != nil {
+		*out = *opts
+	}
+
+	if cursor.NextPageToken != nil {
+		out.NextPageToken = cursor.NextPageToken
+		out.PageNumber = nil
+	}
+
+	if cursor.PageNumber != nil {
+		out.PageNumber = cursor.PageNumber
+		out.NextPageToken = nil
+	}
+
+	return out
+}
@@ -0,0 +1,135 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// tokenPages serves pages by next_page_token, also reporting page_number and
+// page_count like the Zoom users endpoint does.
+func tokenPages(pages ...[]int) pageFunc[int] {
+	return func(ctx context.Context, cursor *PaginationOptions) ([]int, *PaginationResponse, error) {
+		i := 0
+		if cursor.NextPageToken != nil {
+			i, _ = strconv.Atoi(*cursor.NextPageToken)
+		}
+
+		next := ""
+		if i+1 < len(pages) {
+			next = strconv.Itoa(i + 1)
+		}
+
+		return pages[i], &PaginationResponse{NextPageToken: next, PageNumber: 1, PageCount: len(pages)}, nil
+	}
+}
+
+// numberedPages serves pages by page_number.
+func numberedPages(pages ...[]int) pageFunc[int] {
+	return func(ctx context.Context, cursor *PaginationOptions) ([]int, *PaginationResponse, error) {
+		n := 1
+		if cursor.PageNumber != nil {
+			n = *cursor.PageNumber
+		}
+
+		return pages[n-1], &PaginationResponse{PageNumber: n, PageCount: len(pages)}, nil
+	}
+}
+
+func TestCollectAllTokenPagination(t *testing.T) {
+	items, err := collectAll(context.Background(), 0, tokenPages([]int{1, 2}, []int{3, 4}, []int{5}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
+
+func TestCollectAllPageNumberPagination(t *testing.T) {
+	items, err := collectAll(context.Background(), 0, numberedPages([]int{1, 2}, []int{3, 4}, []int{5}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
+
+func TestCollectAllLimit(t *testing.T) {
+	var calls int
+	pages := tokenPages([]int{1, 2}, []int{3, 4}, []int{5})
+	items, err := collectAll(context.Background(), 3, func(ctx context.Context, cursor *PaginationOptions) ([]int, *PaginationResponse, error) {
+		calls++
+		return pages(ctx, cursor)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 3 || items[2] != 3 {
+		t.Fatalf("unexpected items %v", items)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 pages to be fetched, got %d", calls)
+	}
+}
+
+func TestCollectAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pages := tokenPages([]int{1}, []int{2})
+
+	_, err := collectAll(ctx, 0, func(ctx context.Context, cursor *PaginationOptions) ([]int, *PaginationResponse, error) {
+		cancel()
+		return pages(ctx, cursor)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWithPage(t *testing.T) {
+	token := "abc"
+	size := 100
+	opts := &PaginationOptions{NextPageToken: &token, PageSize: &size}
+
+	number := 2
+	got := withPage(opts, &PaginationOptions{PageNumber: &number})
+	if got.NextPageToken != nil || got.PageNumber == nil || *got.PageNumber != 2 || *got.PageSize != 100 {
+		t.Fatalf("unexpected options %+v", got)
+	}
+	if opts.PageNumber != nil {
+		t.Fatal("caller options were modified")
+	}
+}
+
+func TestAutoPaginate(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("next_page_token") {
+		case "":
+			w.Write([]byte(`{"next_page_token":"2","users":[{"id":"a"},{"id":"b"}]}`))
+		case "2":
+			w.Write([]byte(`{"next_page_token":"","users":[{"id":"c"}]}`))
+		default:
+			t.Errorf("unexpected page token %q", r.URL.Query().Get("next_page_token"))
+		}
+	})
+
+	c := newTestClient(t, handler, WithAutoPaginate())
+
+	out, _, err := c.Users.List(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Users) != 3 || out.Users[2].ID != "c" {
+		t.Fatalf("expected all users, got %d", len(out.Users))
+	}
+	if len(out.NextPageToken) != 0 {
+		t.Fatalf("expected an empty next_page_token, got %q", out.NextPageToken)
+	}
+}
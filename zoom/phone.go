@@ -2,30 +2,81 @@ package zoom
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
+// ExtensionNumber is an extension number. Zoom returns it as a JSON number on
+// most endpoints but as a string on some, so both are accepted. It is kept as
+// text so leading zeros and non-numeric extensions survive decoding.
+type ExtensionNumber string
+
+func (n *ExtensionNumber) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		err := json.Unmarshal(b, &s)
+		if err != nil {
+			return fmt.Errorf("invalid extension number %s: %w", b, err)
+		}
+
+		*n = ExtensionNumber(s)
+		return nil
+	}
+
+	var num json.Number
+	err := json.Unmarshal(b, &num)
+	if err != nil {
+		return fmt.Errorf("invalid extension number %s: %w", b, err)
+	}
+
+	*n = ExtensionNumber(num)
+	return nil
+}
+
 type PhoneService struct {
-	client   *Client
-	Accounts *PhoneAccountsService
-	Alerts   *PhoneAlertsService
+	client     *Client
+	Accounts   *PhoneAccountsService
+	Alerts     *PhoneAlertsService
+	Plans      *PhonePlansService
+	Numbers    *PhoneNumbersService
+	Recordings *PhoneRecordingsService
+
+	Reports          *PhoneReportsService
+	SharedLineGroups *PhoneSharedLineGroupsService
 }
 
 type PhoneAccountsService struct {
 	client *Client
 }
 
+// maxCustomizedNumbersPerRequest is the most ids Zoom accepts in one add or
+// delete customized numbers request.
+const maxCustomizedNumbersPerRequest = 30
+
 type AddCustomizedNumbersRequest struct {
 	PhoneNumberIDs []string `json:"phone_number_ids"`
 }
 
 // https://developers.zoom.us/docs/api/phone/#tag/accounts/post/phone/outbound_caller_id/customized_numbers
 func (p *PhoneAccountsService) AddCustomizedNumbers(ctx context.Context, req *AddCustomizedNumbersRequest) (*http.Response, error) {
-	if len(req.PhoneNumberIDs) > 30 {
-		return nil, fmt.Errorf("Error: cannot add more than 30 phone numbers ids at once")
+	if len(req.PhoneNumberIDs) > maxCustomizedNumbersPerRequest {
+		return nil, fmt.Errorf("Error: cannot add more than %d phone numbers ids at once", maxCustomizedNumbersPerRequest)
 	}
 	res, err := p.client.request(ctx, http.MethodPost, "/phone/outbound_caller_id/customized_numbers", nil, req, nil)
 	if err != nil {
@@ -35,14 +86,109 @@ func (p *PhoneAccountsService) AddCustomizedNumbers(ctx context.Context, req *Ad
 	return res, nil
 }
 
+type CustomizedNumberLineError struct {
+	Line          int
+	PhoneNumberID string
+	Err           error
+}
+
+func (e *CustomizedNumberLineError) Error() string {
+	return fmt.Sprintf("line %d (%s): %s", e.Line, e.PhoneNumberID, e.Err)
+}
+
+func (e *CustomizedNumberLineError) Unwrap() error {
+	return e.Err
+}
+
+type AddCustomizedNumbersFromReaderResult struct {
+	Added  []string
+	Failed []*CustomizedNumberLineError
+}
+
+// AddCustomizedNumbersFromReader reads phone number ids from the first CSV
+// column of r, one per line, and adds them as customized numbers in chunks of
+// 30. Blank lines are skipped. Invalid ids, i.e. with characters other than
+// letters, digits, '-' and '_', duplicate ids and ids in a chunk Zoom rejected
+// are reported per line in the result; the returned error is only set if r
+// cannot be read.
+func (p *PhoneAccountsService) AddCustomizedNumbersFromReader(ctx context.Context, r io.Reader) (*AddCustomizedNumbersFromReaderResult, error) {
+	result := &AddCustomizedNumbersFromReaderResult{}
+
+	type line struct {
+		number int
+		id     string
+	}
+
+	var lines []line
+	seen := map[string]bool{}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error reading phone number ids: %w", err)
+		}
+
+		number, _ := reader.FieldPos(0)
+		id := strings.TrimSpace(record[0])
+		switch {
+		case len(id) == 0:
+			continue
+		case !validPhoneNumberID(id):
+			result.Failed = append(result.Failed, &CustomizedNumberLineError{Line: number, PhoneNumberID: id, Err: errors.New("invalid phone number id")})
+		case seen[id]:
+			result.Failed = append(result.Failed, &CustomizedNumberLineError{Line: number, PhoneNumberID: id, Err: errors.New("duplicate phone number id")})
+		default:
+			seen[id] = true
+			lines = append(lines, line{number: number, id: id})
+		}
+	}
+
+	for chunk := range slices.Chunk(lines, maxCustomizedNumbersPerRequest) {
+		ids := make([]string, len(chunk))
+		for i, l := range chunk {
+			ids[i] = l.id
+		}
+
+		_, err := p.AddCustomizedNumbers(ctx, &AddCustomizedNumbersRequest{PhoneNumberIDs: ids})
+		if err != nil {
+			for _, l := range chunk {
+				result.Failed = append(result.Failed, &CustomizedNumberLineError{Line: l.number, PhoneNumberID: l.id, Err: err})
+			}
+
+			continue
+		}
+
+		result.Added = append(result.Added, ids...)
+	}
+
+	return result, nil
+}
+
+// validPhoneNumberID reports whether id looks like a Zoom phone number id,
+// which is made of letters, digits, '-' and '_'.
+func validPhoneNumberID(id string) bool {
+	for _, r := range id {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+
+	return len(id) > 0
+}
+
 type DeleteCustomizedNumbersRequest struct {
 	CustomizedIDs []string `url:"customized_ids"`
 }
 
 // https://developers.zoom.us/docs/api/phone/#tag/accounts/delete/phone/outbound_caller_id/customized_numbers
 func (p *PhoneAccountsService) DeleteCustomizedNumbers(ctx context.Context, req *DeleteCustomizedNumbersRequest) (*http.Response, error) {
-	if len(req.CustomizedIDs) > 30 {
-		return nil, fmt.Errorf("Error: cannot delete more than 30 customized ids at once")
+	if len(req.CustomizedIDs) > maxCustomizedNumbersPerRequest {
+		return nil, fmt.Errorf("Error: cannot delete more than %d customized ids at once", maxCustomizedNumbersPerRequest)
 	}
 	res, err := p.client.request(ctx, http.MethodDelete, "/phone/outbound_caller_id/customized_numbers", req, nil, nil)
 	if err != nil {
@@ -54,19 +200,23 @@ func (p *PhoneAccountsService) DeleteCustomizedNumbers(ctx context.Context, req
 
 type GetCustomizedNumbersRequest struct {
 	*PaginationOptions `url:",omitempty"`
+
+	// Keyword filters by phone number, extension name or extension number.
+	// Zoom calls this parameter keyword on this endpoint.
+	Keyword string `url:"keyword,omitempty"`
 }
 
 type CustomizeNumber struct {
-	CustomizeID     string `json:"customize_id"`
-	DisplayName     string `json:"display_name"`
-	ExtensionID     string `json:"extension_id"`
-	ExtensionName   string `json:"extension_name"`
-	ExtensionNumber string `json:"extension_number"`
-	ExtensionType   string `json:"extension_type"`
-	Incoming        bool   `json:"incoming"`
-	Outgoing        bool   `json:"outgoing"`
-	PhoneNumber     string `json:"phone_number"`
-	PhoneNumberID   string `json:"phone_number_id"`
+	CustomizeID     string          `json:"customize_id"`
+	DisplayName     string          `json:"display_name"`
+	ExtensionID     string          `json:"extension_id"`
+	ExtensionName   string          `json:"extension_name"`
+	ExtensionNumber ExtensionNumber `json:"extension_number"`
+	ExtensionType   string          `json:"extension_type"`
+	Incoming        bool            `json:"incoming"`
+	Outgoing        bool            `json:"outgoing"`
+	PhoneNumber     string          `json:"phone_number"`
+	PhoneNumberID   string          `json:"phone_number_id"`
 	Site            struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
@@ -87,10 +237,45 @@ func (p *PhoneAccountsService) GetCustomizedNumbers(ctx context.Context, req *Ge
 		return nil, res, fmt.Errorf("Error making request: %w", err)
 	}
 
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.CustomizeNumbers, p.customizedNumberPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
 	return out, res, nil
 }
 
-var availableSettingTypes = []string{
+func (p *PhoneAccountsService) customizedNumberPages(req *GetCustomizedNumbersRequest) pageFunc[*CustomizeNumber] {
+	r := GetCustomizedNumbersRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*CustomizeNumber, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.GetCustomizedNumbers(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.CustomizeNumbers, out.PaginationResponse, nil
+	}
+}
+
+// GetAllCustomizedNumbers follows next_page_token until every customized number
+// has been fetched. If limit is greater than zero, at most limit numbers are
+// returned.
+func (p *PhoneAccountsService) GetAllCustomizedNumbers(ctx context.Context, limit int) ([]*CustomizeNumber, error) {
+	return collectAll(ctx, limit, p.customizedNumberPages(nil))
+}
+
+// SettingType is a phone account setting type accepted by GetAccountSettings.
+type SettingType string
+
+var availableSettingTypes = []SettingType{
 	"call_live_transcription",
 	"local_survivability_mode",
 	"external_calling_on_zoom_room_common_area",
@@ -135,14 +320,38 @@ var availableSettingTypes = []string{
 	"block_calls_as_threat",
 }
 
+// AllSettingTypes returns every setting type supported by GetAccountSettings.
+func AllSettingTypes() []SettingType {
+	return slices.Clone(availableSettingTypes)
+}
+
 type AccountSettingsQuery struct {
 	SettingTypes string `url:"setting_type"`
 }
 
+// NewAccountSettingsQuery builds a query requesting the given setting types.
+func NewAccountSettingsQuery(settingTypes ...SettingType) *AccountSettingsQuery {
+	types := make([]string, len(settingTypes))
+	for i, settingType := range settingTypes {
+		types[i] = string(settingType)
+	}
+
+	return &AccountSettingsQuery{SettingTypes: strings.Join(types, ",")}
+}
+
+// LockedBy is the level a locked setting is locked at.
+type LockedBy string
+
+const (
+	LockedByInvalid LockedBy = "invalid"
+	LockedByAccount LockedBy = "account"
+	LockedBySite    LockedBy = "site"
+)
+
 type AccountSettingStates struct {
-	Enable   bool   `json:"enable"`
-	Locked   bool   `json:"locked"`
-	LockedBy string `json:"locked_by"` //invalid, account
+	Enable   bool     `json:"enable"`
+	Locked   bool     `json:"locked"`
+	LockedBy LockedBy `json:"locked_by"`
 }
 
 type AccountSettingsResponse struct {
@@ -270,17 +479,22 @@ type AccountSettingsResponse struct {
 		*AccountSettingStates
 		AllowMusicOnHoldCustomization                 bool `json:"allow_music_on_hold_customization"`
 		AllowVoicemailAndMessageGreetingCustomization bool `json:"allow_voicemail_and_message_greeting_customization"`
-	} `json:"restricted_call_hours"`
+	} `json:"personal_audio_library"`
 	RestrictedCallHours struct {
 		*AccountSettingStates
-	} `json:"personal_audio_library"`
+	} `json:"restricted_call_hours"`
+	SharedVoicemailNotificationByEmail struct {
+		*AccountSettingStates
+		IncludeVoicemailFile          bool `json:"include_voicemail_file"`
+		IncludeVoicemailTranscription bool `json:"include_voicemail_transcription"`
+	} `json:"shared_voicemail_notification_by_email"`
 }
 
 // https://developers.zoom.us/docs/api/phone/#tag/accounts/get/phone/account_settings
 func (p *PhoneAccountsService) GetAccountSettings(ctx context.Context, query *AccountSettingsQuery) (*AccountSettingsResponse, *http.Response, error) {
 	for _, setting := range strings.Split(query.SettingTypes, ",") {
 		setting = strings.TrimSpace(setting)
-		if !slices.Contains(availableSettingTypes, setting) {
+		if !slices.Contains(availableSettingTypes, SettingType(setting)) {
 			return nil, nil, fmt.Errorf("Error: invalid setting type '%s'", setting)
 		}
 	}
@@ -321,6 +535,106 @@ type CreateAlertRequest struct {
 	Status          int      `json:"status"`
 }
 
+var availableAlertFrequencies = []int{5, 10, 15, 30, 60}
+
+var availableAlertTimeFrameTypes = []string{"all_day", "specific_time"}
+
+// Validate checks the request for missing or invalid fields and returns an
+// error describing every problem found.
+//
+// Only threshold rules, such as the number of calls waiting in a queue, take
+// rule_conditions, and which rules those are depends on the module. Validate
+// therefore accepts a request without conditions and leaves that check to
+// Zoom, but rejects conditions that set only a type or only a value.
+func (r *CreateAlertRequest) Validate() error {
+	var errs []error
+
+	if len(r.AlertSettingsName) == 0 {
+		errs = append(errs, errors.New("alert_settings_name is required"))
+	}
+	if r.Module <= 0 {
+		errs = append(errs, fmt.Errorf("invalid module %d", r.Module))
+	}
+	if r.Rule <= 0 {
+		errs = append(errs, fmt.Errorf("invalid rule %d", r.Rule))
+	}
+	if (r.RuleConditions.RuleConditionType != 0 || len(r.RuleConditions.RuleConditionValue) > 0) &&
+		(r.RuleConditions.RuleConditionType <= 0 || len(r.RuleConditions.RuleConditionValue) == 0) {
+		errs = append(errs, errors.New("rule_conditions requires a type and a value"))
+	}
+	if r.TargetType <= 0 {
+		errs = append(errs, fmt.Errorf("invalid target_type %d", r.TargetType))
+	}
+	if len(r.TargetIDs) == 0 {
+		errs = append(errs, errors.New("at least one target id is required"))
+	}
+	if len(r.EmailRecipients) == 0 && len(r.ChatChannels) == 0 {
+		errs = append(errs, errors.New("at least one email recipient or chat channel is required"))
+	}
+	if !slices.Contains(availableAlertFrequencies, r.Frequency) {
+		errs = append(errs, fmt.Errorf("invalid frequency %d, must be one of %v", r.Frequency, availableAlertFrequencies))
+	}
+	if !slices.Contains(availableAlertTimeFrameTypes, r.TimeFrameType) {
+		errs = append(errs, fmt.Errorf("invalid time_frame_type '%s', must be one of %v", r.TimeFrameType, availableAlertTimeFrameTypes))
+	}
+	if r.TimeFrameType == "specific_time" {
+		errs = append(errs, validateAlertTimeFrame(r.TimeFrameFrom, r.TimeFrameTo)...)
+	}
+	if r.Status != 0 && r.Status != 1 {
+		errs = append(errs, fmt.Errorf("invalid status %d", r.Status))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Error: invalid alert request: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// MarshalJSON leaves out rule_conditions when they are unset, for rules that
+// take none.
+func (r CreateAlertRequest) MarshalJSON() ([]byte, error) {
+	type alias CreateAlertRequest
+
+	out := struct {
+		alias
+		RuleConditions any `json:"rule_conditions,omitempty"`
+	}{alias: alias(r)}
+	if r.RuleConditions.RuleConditionType != 0 || len(r.RuleConditions.RuleConditionValue) > 0 {
+		out.RuleConditions = r.RuleConditions
+	}
+
+	return json.Marshal(out)
+}
+
+// alertTimeFrameLayout is the format of time_frame_from and time_frame_to.
+const alertTimeFrameLayout = "15:04:05"
+
+// validateAlertTimeFrame checks a specific_time window, which never fires if
+// it ends before it starts.
+func validateAlertTimeFrame(from, to string) []error {
+	if len(from) == 0 || len(to) == 0 {
+		return []error{errors.New("time_frame_from and time_frame_to are required for specific_time")}
+	}
+
+	var errs []error
+
+	fromTime, err := time.Parse(alertTimeFrameLayout, from)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid time_frame_from '%s', must be HH:mm:ss", from))
+	}
+	toTime, err := time.Parse(alertTimeFrameLayout, to)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid time_frame_to '%s', must be HH:mm:ss", to))
+	}
+
+	if len(errs) == 0 && !fromTime.Before(toTime) {
+		errs = append(errs, fmt.Errorf("time_frame_from %s must be before time_frame_to %s", from, to))
+	}
+
+	return errs
+}
+
 type CreateAlertResponse struct {
 	AlertSettingID   string `json:"alert_setting_id"`
 	AlertSettingName string `json:"alert_setting_name"`
@@ -328,6 +642,11 @@ type CreateAlertResponse struct {
 
 // https://developers.zoom.us/docs/api/phone/#tag/alerts/post/phone/alert_settings
 func (p *PhoneAlertsService) CreateAlert(ctx context.Context, req *CreateAlertRequest) (*CreateAlertResponse, *http.Response, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	out := &CreateAlertResponse{}
 
 	res, err := p.client.request(ctx, http.MethodPost, "/phone/alert_settings", nil, req, out)
@@ -368,4 +687,1101 @@ type GetAlertSettingsResponse struct {
 	Frequency       int      `json:"frequency"`
 	Module          int      `json:"module"`
 	Rule            int      `json:"rule"`
+	RuleConditions  struct {
+		RuleConditionType  int    `json:"rule_condition_type"`
+		RuleConditionValue string `json:"rule_condition_value"`
+	} `json:"rule_conditions"`
+	Status        int      `json:"status"`
+	TargetIDs     []string `json:"target_ids"`
+	TargetType    int      `json:"target_type"`
+	TimeFrameFrom string   `json:"time_frame_from"`
+	TimeFrameTo   string   `json:"time_frame_to"`
+	TimeFrameType string   `json:"time_frame_type"`
+}
+
+// CreateAlertRequest returns the request recreating this alert setting, e.g.
+// to import an exported alert with ImportAlerts.
+func (r *GetAlertSettingsResponse) CreateAlertRequest() *CreateAlertRequest {
+	return &CreateAlertRequest{
+		AlertSettingsName: r.AlertSettingName,
+		Module:            r.Module,
+		Rule:              r.Rule,
+		RuleConditions:    r.RuleConditions,
+		TargetType:        r.TargetType,
+		TimeFrameFrom:     r.TimeFrameFrom,
+		TimeFrameTo:       r.TimeFrameTo,
+		TimeFrameType:     r.TimeFrameType,
+		ChatChannels:      r.ChatChannels,
+		EmailRecipients:   r.EmailRecipients,
+		Frequency:         r.Frequency,
+		TargetIDs:         r.TargetIDs,
+		Status:            r.Status,
+	}
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/alerts/get/phone/alert_settings/%7BalertSettingId%7D
+func (p *PhoneAlertsService) GetAlertSettings(ctx context.Context, req *GetAlertSettingsRequest) (*GetAlertSettingsResponse, *http.Response, error) {
+	out := &GetAlertSettingsResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, fmt.Sprintf("/phone/alert_settings/%s", url.PathEscape(req.AlertSettingID)), nil, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	return out, res, nil
+}
+
+// UpdateAlertSettingsRequest changes the given fields of an alert setting.
+// Zero values are left unchanged, except that a non-nil EmailRecipients or
+// ChatChannels replaces the current list, so a pointer to an empty list clears
+// it. The module and rule of a setting cannot be changed.
+type UpdateAlertSettingsRequest struct {
+	AlertSettingsName string `json:"alert_settings_name,omitempty"`
+	RuleConditions    *struct {
+		RuleConditionType  int    `json:"rule_condition_type"`
+		RuleConditionValue string `json:"rule_condition_value"`
+	} `json:"rule_conditions,omitempty"`
+	TargetIDs     []string `json:"target_ids,omitempty"`
+	TimeFrameFrom string   `json:"time_frame_from,omitempty"`
+	TimeFrameTo   string   `json:"time_frame_to,omitempty"`
+	TimeFrameType string   `json:"time_frame_type,omitempty"`
+	ChatChannels  *[]struct {
+		ChatChannelName string `json:"chat_channel_name"`
+		EndPoint        string `json:"endpoint"`
+		Token           string `json:"token"`
+	} `json:"chat_channels,omitempty"`
+	EmailRecipients *[]string `json:"email_recipients,omitempty"`
+	Frequency       int       `json:"frequency,omitempty"`
+	Status          *int      `json:"status,omitempty"`
+}
+
+// Validate checks the fields set on the request, with the same rules as
+// CreateAlertRequest.Validate, and returns an error describing every problem
+// found.
+func (r *UpdateAlertSettingsRequest) Validate() error {
+	var errs []error
+
+	if r.RuleConditions != nil && (r.RuleConditions.RuleConditionType <= 0 || len(r.RuleConditions.RuleConditionValue) == 0) {
+		errs = append(errs, errors.New("rule_conditions requires a type and a value"))
+	}
+	if r.EmailRecipients != nil && r.ChatChannels != nil && len(*r.EmailRecipients) == 0 && len(*r.ChatChannels) == 0 {
+		errs = append(errs, errors.New("at least one email recipient or chat channel is required"))
+	}
+	if r.Frequency != 0 && !slices.Contains(availableAlertFrequencies, r.Frequency) {
+		errs = append(errs, fmt.Errorf("invalid frequency %d, must be one of %v", r.Frequency, availableAlertFrequencies))
+	}
+	if len(r.TimeFrameType) > 0 && !slices.Contains(availableAlertTimeFrameTypes, r.TimeFrameType) {
+		errs = append(errs, fmt.Errorf("invalid time_frame_type '%s', must be one of %v", r.TimeFrameType, availableAlertTimeFrameTypes))
+	}
+	if r.TimeFrameType == "specific_time" {
+		errs = append(errs, validateAlertTimeFrame(r.TimeFrameFrom, r.TimeFrameTo)...)
+	}
+	if r.Status != nil && *r.Status != 0 && *r.Status != 1 {
+		errs = append(errs, fmt.Errorf("invalid status %d", *r.Status))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Error: invalid alert request: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/alerts/patch/phone/alert_settings/%7BalertSettingId%7D
+func (p *PhoneAlertsService) UpdateAlertSettings(ctx context.Context, alertSettingID string, req *UpdateAlertSettingsRequest) (*http.Response, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.client.request(ctx, http.MethodPatch, fmt.Sprintf("/phone/alert_settings/%s", url.PathEscape(alertSettingID)), nil, req, nil)
+	if err != nil {
+		return res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	return res, nil
+}
+
+// AlertModule is the module an alert setting belongs to, such as call queue
+// management. Zoom numbers modules from 1.
+type AlertModule int
+
+// AlertRule is the rule an alert setting checks. Zoom numbers rules from 1,
+// and which rules apply depends on the module.
+type AlertRule int
+
+// AlertStatus is whether an alert setting is active.
+type AlertStatus int
+
+const (
+	AlertStatusInactive AlertStatus = 0
+	AlertStatusActive   AlertStatus = 1
+)
+
+// ListAlertSettingsRequest filters alert settings. A nil filter matches every
+// value.
+type ListAlertSettingsRequest struct {
+	*PaginationOptions `url:",omitempty"`
+
+	Module *AlertModule `url:"module,omitempty"`
+	Rule   *AlertRule   `url:"rule,omitempty"`
+	Status *AlertStatus `url:"status,omitempty"`
+}
+
+// Validate returns an error if a filter is set to a value Zoom does not
+// accept.
+func (r *ListAlertSettingsRequest) Validate() error {
+	var errs []error
+
+	if r.Module != nil && *r.Module <= 0 {
+		errs = append(errs, fmt.Errorf("invalid module %d", *r.Module))
+	}
+	if r.Rule != nil && *r.Rule <= 0 {
+		errs = append(errs, fmt.Errorf("invalid rule %d", *r.Rule))
+	}
+	if r.Status != nil && *r.Status != AlertStatusInactive && *r.Status != AlertStatusActive {
+		errs = append(errs, fmt.Errorf("invalid status %d", *r.Status))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Error: invalid alert settings filter: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+type AlertSetting struct {
+	AlertSettingID   string      `json:"alert_setting_id"`
+	AlertSettingName string      `json:"alert_setting_name"`
+	Module           AlertModule `json:"module"`
+	Rule             AlertRule   `json:"rule"`
+	Status           AlertStatus `json:"status"`
+}
+
+type ListAlertSettingsResponse struct {
+	*PaginationResponse
+	AlertSettings []*AlertSetting `json:"alert_settings"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/alerts/get/phone/alert_settings
+func (p *PhoneAlertsService) ListAlertSettings(ctx context.Context, req *ListAlertSettingsRequest) (*ListAlertSettingsResponse, *http.Response, error) {
+	if req != nil {
+		err := req.Validate()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	out := &ListAlertSettingsResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/alert_settings", req, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.AlertSettings, p.alertSettingPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	return out, res, nil
+}
+
+func (p *PhoneAlertsService) alertSettingPages(req *ListAlertSettingsRequest) pageFunc[*AlertSetting] {
+	r := ListAlertSettingsRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*AlertSetting, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.ListAlertSettings(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.AlertSettings, out.PaginationResponse, nil
+	}
+}
+
+// GetAllAlertSettings pages through the alert settings matching req, which may
+// be nil. If limit is greater than zero, at most limit settings are returned.
+func (p *PhoneAlertsService) GetAllAlertSettings(ctx context.Context, req *ListAlertSettingsRequest, limit int) ([]*AlertSetting, error) {
+	if req != nil {
+		err := req.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return collectAll(ctx, limit, p.alertSettingPages(req))
+}
+
+var (
+	ErrAlertSettingNotFound  = errors.New("alert setting not found")
+	ErrAlertSettingAmbiguous = errors.New("more than one alert setting has this name")
+)
+
+// GetAlertSettingByName pages through the alert settings and returns the
+// details of the one named name. It returns ErrAlertSettingNotFound if none
+// match and ErrAlertSettingAmbiguous if several do.
+func (p *PhoneAlertsService) GetAlertSettingByName(ctx context.Context, name string) (*GetAlertSettingsResponse, error) {
+	settings, err := collectAll(ctx, 0, p.alertSettingPages(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := alertSettingByName(settings, name)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := p.GetAlertSettings(ctx, &GetAlertSettingsRequest{AlertSettingID: match.AlertSettingID})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// alertSettingByName returns the only setting named name, or
+// ErrAlertSettingNotFound or ErrAlertSettingAmbiguous.
+func alertSettingByName(settings []*AlertSetting, name string) (*AlertSetting, error) {
+	var match *AlertSetting
+	for _, setting := range settings {
+		if setting.AlertSettingName != name {
+			continue
+		}
+
+		if match != nil {
+			return nil, fmt.Errorf("Error: '%s': %w", name, ErrAlertSettingAmbiguous)
+		}
+
+		match = setting
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("Error: '%s': %w", name, ErrAlertSettingNotFound)
+	}
+
+	return match, nil
+}
+
+// CreateAlertIfAbsent creates the alert unless one with the same name already
+// exists, in which case the existing alert is returned and created is false.
+func (p *PhoneAlertsService) CreateAlertIfAbsent(ctx context.Context, req *CreateAlertRequest) (out *CreateAlertResponse, created bool, err error) {
+	existing, err := p.GetAlertSettingByName(ctx, req.AlertSettingsName)
+	if err == nil {
+		return &CreateAlertResponse{AlertSettingID: existing.AlertSettingID, AlertSettingName: existing.AlertSettingName}, false, nil
+	}
+	if !errors.Is(err, ErrAlertSettingNotFound) {
+		return nil, false, err
+	}
+
+	out, _, err = p.CreateAlert(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out, true, nil
+}
+
+// ExportAlerts returns the details of every alert setting, e.g. to keep them
+// in version control and restore them with ImportAlerts.
+func (p *PhoneAlertsService) ExportAlerts(ctx context.Context) ([]*GetAlertSettingsResponse, error) {
+	settings, err := collectAll(ctx, 0, p.alertSettingPages(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*GetAlertSettingsResponse, 0, len(settings))
+	for _, setting := range settings {
+		details, _, err := p.GetAlertSettings(ctx, &GetAlertSettingsRequest{AlertSettingID: setting.AlertSettingID})
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, details)
+	}
+
+	return out, nil
+}
+
+type ImportAlertsResult struct {
+	// Created and Updated are the names of the alerts created and updated.
+	Created []string
+	Updated []string
+}
+
+// ImportAlerts creates each alert, or updates the existing alert with the same
+// name, so importing the same alerts twice changes nothing. Alerts whose name
+// matches several settings, or an existing setting with a different module or
+// rule, are not imported. Failures are returned as a *BatchError keyed by
+// alert name, alongside the result for the alerts that were imported.
+func (p *PhoneAlertsService) ImportAlerts(ctx context.Context, alerts []*CreateAlertRequest) (*ImportAlertsResult, error) {
+	settings, err := collectAll(ctx, 0, p.alertSettingPages(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportAlertsResult{}
+	errs := map[string]error{}
+
+	for _, alert := range alerts {
+		existing, err := alertSettingByName(settings, alert.AlertSettingsName)
+		switch {
+		case errors.Is(err, ErrAlertSettingNotFound):
+			_, _, err = p.CreateAlert(ctx, alert)
+			if err == nil {
+				result.Created = append(result.Created, alert.AlertSettingsName)
+			}
+		case err != nil:
+		case existing.Module != AlertModule(alert.Module) || existing.Rule != AlertRule(alert.Rule):
+			err = fmt.Errorf("Error: '%s' has module %d and rule %d, which cannot be changed", alert.AlertSettingsName, existing.Module, existing.Rule)
+		default:
+			err = alert.Validate()
+			if err == nil {
+				_, err = p.UpdateAlertSettings(ctx, existing.AlertSettingID, alertUpdate(alert))
+			}
+			if err == nil {
+				result.Updated = append(result.Updated, alert.AlertSettingsName)
+			}
+		}
+
+		if err != nil {
+			errs[alert.AlertSettingsName] = err
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, &BatchError{Errors: errs}
+	}
+
+	return result, nil
+}
+
+// alertUpdate returns the update making an existing alert match alert. The
+// notification lists are always sent, so recipients and channels dropped from
+// alert are removed. Rule conditions are only sent when alert has some, as
+// rules without conditions are valid.
+func alertUpdate(alert *CreateAlertRequest) *UpdateAlertSettingsRequest {
+	chatChannels := alert.ChatChannels
+	if chatChannels == nil {
+		chatChannels = make([]struct {
+			ChatChannelName string `json:"chat_channel_name"`
+			EndPoint        string `json:"endpoint"`
+			Token           string `json:"token"`
+		}, 0)
+	}
+
+	emailRecipients := alert.EmailRecipients
+	if emailRecipients == nil {
+		emailRecipients = []string{}
+	}
+
+	update := &UpdateAlertSettingsRequest{
+		AlertSettingsName: alert.AlertSettingsName,
+		TargetIDs:         alert.TargetIDs,
+		TimeFrameFrom:     alert.TimeFrameFrom,
+		TimeFrameTo:       alert.TimeFrameTo,
+		TimeFrameType:     alert.TimeFrameType,
+		ChatChannels:      &chatChannels,
+		EmailRecipients:   &emailRecipients,
+		Frequency:         alert.Frequency,
+		Status:            &alert.Status,
+	}
+	if alert.RuleConditions.RuleConditionType != 0 || len(alert.RuleConditions.RuleConditionValue) > 0 {
+		update.RuleConditions = &alert.RuleConditions
+	}
+
+	return update
+}
+
+// CallHandlingSettingType is the settingType path segment of the extension
+// call handling endpoints.
+type CallHandlingSettingType string
+
+const (
+	CallHandlingSettingTypeBusinessHours  CallHandlingSettingType = "business_hours"
+	CallHandlingSettingTypeClosedHours    CallHandlingSettingType = "closed_hours"
+	CallHandlingSettingTypeHolidayHours   CallHandlingSettingType = "holiday_hours"
+	CallHandlingSettingTypeCallForwarding CallHandlingSettingType = "call_forwarding"
+	CallHandlingSettingTypeHoliday        CallHandlingSettingType = "holiday"
+)
+
+var availableCallHandlingSettingTypes = []CallHandlingSettingType{
+	CallHandlingSettingTypeBusinessHours,
+	CallHandlingSettingTypeClosedHours,
+	CallHandlingSettingTypeHolidayHours,
+	CallHandlingSettingTypeCallForwarding,
+	CallHandlingSettingTypeHoliday,
+}
+
+// Validate returns an error if t is not a known call handling setting type,
+// so a mistyped path segment fails before a request is built.
+func (t CallHandlingSettingType) Validate() error {
+	if !slices.Contains(availableCallHandlingSettingTypes, t) {
+		return fmt.Errorf("Error: invalid call handling setting type '%s'", t)
+	}
+
+	return nil
+}
+
+type PhonePlansService struct {
+	client *Client
+}
+
+type CallingPlan struct {
+	Assigned           int    `json:"assigned"`
+	Available          int    `json:"available"`
+	BillingAccountID   string `json:"billing_account_id"`
+	BillingAccountName string `json:"billing_account_name"`
+	Name               string `json:"name"`
+	Subscribed         int    `json:"subscribed"`
+	Type               int    `json:"type"`
+}
+
+type ListCallingPlansResponse struct {
+	CallingPlans []*CallingPlan `json:"calling_plans"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/phone-plans/get/phone/calling_plans
+func (p *PhonePlansService) ListCallingPlans(ctx context.Context) (*ListCallingPlansResponse, *http.Response, error) {
+	out := &ListCallingPlansResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/calling_plans", nil, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	return out, res, nil
+}
+
+// PlanType is Zoom's numeric code for a plan, such as a calling plan or a
+// phone number package.
+type PlanType int
+
+// PlanDate is the start or end date of a plan. Zoom sends it either as a
+// yyyy-mm-dd date or as an RFC 3339 timestamp; empty dates are zero.
+type PlanDate struct {
+	time.Time
+}
+
+func (d *PlanDate) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return fmt.Errorf("Error: invalid plan date %s: %w", b, err)
+	}
+	if len(s) == 0 {
+		return nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		return fmt.Errorf("Error: invalid plan date '%s': %w", s, err)
+	}
+
+	d.Time = t
+
+	return nil
+}
+
+type Plan struct {
+	Assigned           int      `json:"assigned"`
+	Available          int      `json:"available"`
+	BillingAccountID   string   `json:"billing_account_id"`
+	BillingAccountName string   `json:"billing_account_name"`
+	EndDate            PlanDate `json:"end_date"`
+	Name               string   `json:"name"`
+	StartDate          PlanDate `json:"start_date"`
+	Subscribed         int      `json:"subscribed"`
+	Type               PlanType `json:"type"`
+}
+
+type PlanInformation struct {
+	PlanBase struct {
+		CalloutCountries []struct {
+			Code string `json:"code"`
+			Name string `json:"name"`
+		} `json:"callout_countries"`
+		DeductionMethod string `json:"deduction_method"`
+	} `json:"plan_base"`
+	PlanCalling []*Plan `json:"plan_calling"`
+	PlanNumber  []*Plan `json:"plan_number"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/phone-plans/get/phone/plans
+func (p *PhonePlansService) GetPlanInformation(ctx context.Context) (*PlanInformation, *http.Response, error) {
+	out := &PlanInformation{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/plans", nil, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	return out, res, nil
+}
+
+type CallingPlanUsage struct {
+	Type      int
+	Name      string
+	Total     int
+	Assigned  int
+	Available int
+}
+
+// GetCallingPlanUsage summarizes subscribed, assigned and available licenses
+// per calling plan type, adding up plans split across billing accounts.
+func (p *PhonePlansService) GetCallingPlanUsage(ctx context.Context) ([]*CallingPlanUsage, error) {
+	plans, _, err := p.ListCallingPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage []*CallingPlanUsage
+	byType := map[int]*CallingPlanUsage{}
+	for _, plan := range plans.CallingPlans {
+		u, ok := byType[plan.Type]
+		if !ok {
+			u = &CallingPlanUsage{Type: plan.Type, Name: plan.Name}
+			byType[plan.Type] = u
+			usage = append(usage, u)
+		}
+
+		u.Total += plan.Subscribed
+		u.Assigned += plan.Assigned
+		u.Available += plan.Available
+	}
+
+	return usage, nil
+}
+
+var (
+	ErrPhoneNumberNotFound   = errors.New("phone number not found")
+	ErrPhoneNumberUnassigned = errors.New("phone number is not assigned")
+)
+
+type PhoneNumbersService struct {
+	client *Client
+}
+
+type ListPhoneNumbersRequest struct {
+	*PaginationOptions `url:",omitempty"`
+
+	Type           string `url:"type,omitempty"` // assigned, unassigned, byoc, all
+	ExtensionType  string `url:"extension_type,omitempty"`
+	NumberType     string `url:"number_type,omitempty"`
+	PendingNumbers *bool  `url:"pending_numbers,omitempty"`
+	SiteID         string `url:"site_id,omitempty"`
+	// Keyword filters by phone number. Zoom calls this parameter keyword on
+	// this endpoint.
+	Keyword string `url:"keyword,omitempty"`
+}
+
+type PhoneNumberAssignee struct {
+	ExtensionNumber ExtensionNumber `json:"extension_number"`
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+}
+
+// PhoneNumberStatus is whether a phone number can be used yet. Numbers being
+// ported in are pending until the port completes.
+type PhoneNumberStatus string
+
+const (
+	PhoneNumberStatusPending   PhoneNumberStatus = "pending"
+	PhoneNumberStatusAvailable PhoneNumberStatus = "available"
+)
+
+type PhoneNumber struct {
+	Assignee   *PhoneNumberAssignee `json:"assignee"`
+	Capability []string             `json:"capability"`
+	Carrier    struct {
+		Code int    `json:"code"`
+		Name string `json:"name"`
+	} `json:"carrier"`
+	DisplayName string `json:"display_name"`
+	ID          string `json:"id"`
+	Location    string `json:"location"`
+	Number      string `json:"number"`
+	NumberType  string `json:"number_type"`
+	Site        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"site"`
+	Source string            `json:"source"`
+	Status PhoneNumberStatus `json:"status"`
+}
+
+type ListPhoneNumbersResponse struct {
+	*PaginationResponse
+	PhoneNumbers []*PhoneNumber `json:"phone_numbers"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/phone-numbers/get/phone/numbers
+func (p *PhoneNumbersService) ListPhoneNumbers(ctx context.Context, req *ListPhoneNumbersRequest) (*ListPhoneNumbersResponse, *http.Response, error) {
+	out := &ListPhoneNumbersResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/numbers", req, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.PhoneNumbers, p.phoneNumberPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	return out, res, nil
+}
+
+func (p *PhoneNumbersService) phoneNumberPages(req *ListPhoneNumbersRequest) pageFunc[*PhoneNumber] {
+	r := ListPhoneNumbersRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*PhoneNumber, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.ListPhoneNumbers(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.PhoneNumbers, out.PaginationResponse, nil
+	}
+}
+
+// ListPortingNumbers returns the account's phone numbers that are still
+// pending, such as numbers being ported in from another carrier.
+func (p *PhoneNumbersService) ListPortingNumbers(ctx context.Context) ([]*PhoneNumber, error) {
+	pending := true
+
+	numbers, err := collectAll(ctx, 0, p.phoneNumberPages(&ListPhoneNumbersRequest{Type: "all", PendingNumbers: &pending}))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(numbers, func(n *PhoneNumber) bool {
+		return n.Status != PhoneNumberStatusPending
+	}), nil
+}
+
+// GetExtensionIDByNumber looks up the extension a phone number, in E.164
+// format, is assigned to. It returns ErrPhoneNumberNotFound if the account has
+// no such number and ErrPhoneNumberUnassigned if it is not assigned.
+func (p *PhoneNumbersService) GetExtensionIDByNumber(ctx context.Context, number string) (*PhoneNumberAssignee, error) {
+	numbers, err := collectAll(ctx, 0, p.phoneNumberPages(&ListPhoneNumbersRequest{Type: "all", Keyword: number}))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range numbers {
+		if n.Number != number {
+			continue
+		}
+
+		if n.Assignee == nil || len(n.Assignee.ID) == 0 {
+			return nil, fmt.Errorf("Error: %s: %w", number, ErrPhoneNumberUnassigned)
+		}
+
+		return n.Assignee, nil
+	}
+
+	return nil, fmt.Errorf("Error: %s: %w", number, ErrPhoneNumberNotFound)
+}
+
+type PhoneRecordingsService struct {
+	client *Client
+}
+
+type ListAccountRecordingsRequest struct {
+	*PaginationOptions `url:",omitempty"`
+
+	// From and To bound the recording start date, formatted yyyy-mm-dd.
+	From          string `url:"from,omitempty"`
+	To            string `url:"to,omitempty"`
+	OwnerType     string `url:"owner_type,omitempty"`     // all, user, callQueue, commonArea
+	RecordingType string `url:"recording_type,omitempty"` // All, OnDemand, Automatic
+	SiteID        string `url:"site_id,omitempty"`
+}
+
+type Recording struct {
+	CallID           string    `json:"call_id"`
+	CallLogID        string    `json:"call_log_id"`
+	CalleeName       string    `json:"callee_name"`
+	CalleeNumber     string    `json:"callee_number"`
+	CalleeNumberType int       `json:"callee_number_type"`
+	CallerName       string    `json:"caller_name"`
+	CallerNumber     string    `json:"caller_number"`
+	CallerNumberType int       `json:"caller_number_type"`
+	DateTime         time.Time `json:"date_time"`
+	Direction        string    `json:"direction"`
+	DownloadURL      string    `json:"download_url"`
+	Duration         int       `json:"duration"`
+	EndTime          time.Time `json:"end_time"`
+	ID               string    `json:"id"`
+	Owner            struct {
+		ExtensionNumber ExtensionNumber `json:"extension_number"`
+		ID              string          `json:"id"`
+		Name            string          `json:"name"`
+		Type            string          `json:"type"`
+	} `json:"owner"`
+	RecordingType string `json:"recording_type"`
+	Site          struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"site"`
+}
+
+type ListAccountRecordingsResponse struct {
+	*PaginationResponse
+	From       string       `json:"from"`
+	To         string       `json:"to"`
+	Recordings []*Recording `json:"recordings"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/recordings/get/phone/recordings
+func (p *PhoneRecordingsService) ListAccountRecordings(ctx context.Context, req *ListAccountRecordingsRequest) (*ListAccountRecordingsResponse, *http.Response, error) {
+	if req != nil && len(req.From) > 0 && len(req.To) > 0 {
+		from, err := time.Parse(time.DateOnly, req.From)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error: invalid from date '%s': %w", req.From, err)
+		}
+
+		to, err := time.Parse(time.DateOnly, req.To)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error: invalid to date '%s': %w", req.To, err)
+		}
+
+		if from.After(to) {
+			return nil, nil, fmt.Errorf("Error: from date %s is after to date %s", req.From, req.To)
+		}
+	}
+
+	out := &ListAccountRecordingsResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/recordings", req, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.Recordings, p.recordingPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	return out, res, nil
+}
+
+func (p *PhoneRecordingsService) recordingPages(req *ListAccountRecordingsRequest) pageFunc[*Recording] {
+	r := ListAccountRecordingsRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*Recording, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.ListAccountRecordings(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.Recordings, out.PaginationResponse, nil
+	}
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/recordings/get/phone/recording/download/{fileId}
+func (p *PhoneRecordingsService) DownloadRecording(ctx context.Context, recordingID string, w io.Writer) (*http.Response, error) {
+	res, err := p.client.request(ctx, http.MethodGet, fmt.Sprintf("/phone/recording/download/%s", url.PathEscape(recordingID)), nil, nil, w)
+	if err != nil {
+		return res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	return res, nil
+}
+
+// DownloadRecordingsTo downloads each recording into dir, in a file named by
+// its id, running at most concurrency downloads at a time. Files that already
+// exist are skipped, so an interrupted run can be resumed; partial downloads
+// are written to a temporary file and only renamed once complete. Rate limited
+// downloads are retried according to the client's retry policy. Failures are
+// returned as a *BatchError.
+func (p *PhoneRecordingsService) DownloadRecordingsTo(ctx context.Context, recordingIDs []string, dir string, concurrency int) error {
+	ctx = WithRetryOn(ctx, http.StatusTooManyRequests)
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs = map[string]error{}
+		sem  = make(chan struct{}, max(concurrency, 1))
+	)
+
+	fail := func(id string, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		errs[id] = err
+	}
+
+loop:
+	for i, id := range recordingIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for _, id := range recordingIDs[i:] {
+				fail(id, ctx.Err())
+			}
+			break loop
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := p.downloadRecordingTo(ctx, id, dir)
+			if err != nil {
+				fail(id, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+
+	return nil
+}
+
+func (p *PhoneRecordingsService) downloadRecordingTo(ctx context.Context, recordingID string, dir string) error {
+	if recordingID == "" || filepath.Base(recordingID) != recordingID {
+		return fmt.Errorf("Error: invalid recording id '%s'", recordingID)
+	}
+
+	name := filepath.Join(dir, recordingID)
+
+	_, err := os.Stat(name)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Error checking for existing file: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, recordingID+".*.partial")
+	if err != nil {
+		return fmt.Errorf("Error creating file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = p.DownloadRecording(ctx, recordingID, f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("Error closing file: %w", err)
+	}
+
+	err = os.Rename(f.Name(), name)
+	if err != nil {
+		return fmt.Errorf("Error renaming file: %w", err)
+	}
+
+	return nil
+}
+
+var ErrSharedLineGroupNotFound = errors.New("no shared line group has this phone number")
+
+type PhoneSharedLineGroupsService struct {
+	client *Client
+}
+
+type ListSharedLineGroupsRequest struct {
+	*PaginationOptions `url:",omitempty"`
+}
+
+type SharedLineGroup struct {
+	DisplayName     string          `json:"display_name"`
+	ExtensionNumber ExtensionNumber `json:"extension_number"`
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	PhoneNumbers    []struct {
+		ID     string `json:"id"`
+		Number string `json:"number"`
+	} `json:"phone_numbers"`
+	Site struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"site"`
+	Status string `json:"status"`
+}
+
+type ListSharedLineGroupsResponse struct {
+	*PaginationResponse
+	SharedLineGroups []*SharedLineGroup `json:"shared_line_groups"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/shared-line-group/get/phone/shared_line_groups
+func (p *PhoneSharedLineGroupsService) ListSharedLineGroups(ctx context.Context, req *ListSharedLineGroupsRequest) (*ListSharedLineGroupsResponse, *http.Response, error) {
+	out := &ListSharedLineGroupsResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/shared_line_groups", req, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.SharedLineGroups, p.sharedLineGroupPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	return out, res, nil
+}
+
+func (p *PhoneSharedLineGroupsService) sharedLineGroupPages(req *ListSharedLineGroupsRequest) pageFunc[*SharedLineGroup] {
+	r := ListSharedLineGroupsRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*SharedLineGroup, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.ListSharedLineGroups(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.SharedLineGroups, out.PaginationResponse, nil
+	}
+}
+
+// FindSharedLineGroupByNumber pages through the shared line groups and returns
+// the one the phone number, in E.164 format, is assigned to. It returns
+// ErrSharedLineGroupNotFound if none has it.
+func (p *PhoneSharedLineGroupsService) FindSharedLineGroupByNumber(ctx context.Context, number string) (*SharedLineGroup, error) {
+	groups, err := collectAll(ctx, 0, p.sharedLineGroupPages(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		for _, n := range group.PhoneNumbers {
+			if n.Number == number {
+				return group, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Error: %s: %w", number, ErrSharedLineGroupNotFound)
+}
+
+type PhoneReportsService struct {
+	client *Client
+}
+
+type ListOperationLogsRequest struct {
+	*PaginationOptions `url:",omitempty"`
+
+	// From and To bound the log date, formatted yyyy-mm-dd.
+	From         string `url:"from,omitempty"`
+	To           string `url:"to,omitempty"`
+	CategoryType string `url:"category_type,omitempty"`
+}
+
+type OperationLog struct {
+	Action          string    `json:"action"`
+	CategoryType    string    `json:"category_type"`
+	OperationDetail string    `json:"operation_detail"`
+	Operator        string    `json:"operator"`
+	TimeStamp       time.Time `json:"time_stamp"`
+}
+
+type ListOperationLogsResponse struct {
+	*PaginationResponse
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	OperationLogs []*OperationLog `json:"operation_logs"`
+}
+
+// https://developers.zoom.us/docs/api/phone/#tag/reports/get/phone/reports/operationlogs
+func (p *PhoneReportsService) ListOperationLogs(ctx context.Context, req *ListOperationLogsRequest) (*ListOperationLogsResponse, *http.Response, error) {
+	out := &ListOperationLogsResponse{}
+
+	res, err := p.client.request(ctx, http.MethodGet, "/phone/reports/operationlogs", req, nil, out)
+	if err != nil {
+		return nil, res, fmt.Errorf("Error making request: %w", err)
+	}
+
+	if p.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.OperationLogs, p.operationLogPages(req))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	return out, res, nil
+}
+
+func (p *PhoneReportsService) operationLogPages(req *ListOperationLogsRequest) pageFunc[*OperationLog] {
+	r := ListOperationLogsRequest{}
+	if req != nil {
+		r = *req
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*OperationLog, *PaginationResponse, error) {
+		r.PaginationOptions = withPage(r.PaginationOptions, cursor)
+
+		out, _, err := p.ListOperationLogs(ctx, &r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.OperationLogs, out.PaginationResponse, nil
+	}
+}
+
+// ListOperationLogsByOperator pages through the operation logs matching req
+// and returns those made by operator, the admin's email address. Zoom has no
+// server-side operator filter, so every page in the range is fetched.
+func (p *PhoneReportsService) ListOperationLogsByOperator(ctx context.Context, req *ListOperationLogsRequest, operator string) ([]*OperationLog, error) {
+	logs, err := collectAll(ctx, 0, p.operationLogPages(req))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*OperationLog
+	for _, log := range logs {
+		if strings.EqualFold(log.Operator, operator) {
+			out = append(out, log)
+		}
+	}
+
+	return out, nil
 }
@@ -0,0 +1,933 @@
+package zoom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExtensionNumberAcceptsStringsAndNumbers(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"customize_numbers": [
+				{"customize_id": "a", "extension_number": "1001"},
+				{"customize_id": "b", "extension_number": 1002},
+				{"customize_id": "c", "extension_number": ""},
+				{"customize_id": "d", "extension_number": "0123"},
+				{"customize_id": "e", "extension_number": "12a"},
+				{"customize_id": "f", "extension_number": null}
+			]
+		}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	out, _, err := c.Phone.Accounts.GetCustomizedNumbers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ExtensionNumber{"1001", "1002", "", "0123", "12a", ""}
+	for i, w := range want {
+		if got := out.CustomizeNumbers[i].ExtensionNumber; got != w {
+			t.Errorf("number %d: expected %q, got %q", i, w, got)
+		}
+	}
+}
+
+func TestExtensionNumberRejectsGarbage(t *testing.T) {
+	for _, b := range []string{`true`, `{}`, `[1]`} {
+		var n ExtensionNumber
+		if err := n.UnmarshalJSON([]byte(b)); err == nil {
+			t.Errorf("%s: expected an error", b)
+		}
+	}
+}
+
+func TestDownloadRecordingsTo(t *testing.T) {
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		id := strings.TrimPrefix(r.URL.Path, "/phone/recording/download/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": 404, "message": "File does not exist."}`))
+			return
+		}
+
+		w.Write([]byte("audio " + id))
+	})
+
+	c := newTestClient(t, handler)
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "existing"), []byte("kept"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.Phone.Recordings.DownloadRecordingsTo(context.Background(), []string{"a", "b", "existing", "missing"}, dir, 2)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *BatchError, got %v", err)
+	}
+	if len(batchErr.Errors) != 1 || batchErr.Errors["missing"] == nil {
+		t.Errorf("expected only missing to fail, got %v", batchErr.Errors)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", requests.Load())
+	}
+
+	for id, want := range map[string]string{"a": "audio a", "b": "audio b", "existing": "kept"} {
+		b, err := os.ReadFile(filepath.Join(dir, id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != want {
+			t.Errorf("%s: expected %q, got %q", id, want, b)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("expected no partial files to be left, got %d entries", len(entries))
+	}
+}
+
+func TestGetAllAlertSettingsFilters(t *testing.T) {
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Query().Get("module") != "2" || r.URL.Query().Get("status") != "0" || r.URL.Query().Has("rule") {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+
+		if r.URL.Query().Get("next_page_token") == "" {
+			w.Write([]byte(`{"next_page_token": "next", "alert_settings": [{"alert_setting_id": "a"}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"alert_settings": [{"alert_setting_id": "b"}]}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	module := AlertModule(2)
+	status := AlertStatusInactive
+	settings, err := c.Phone.Alerts.GetAllAlertSettings(context.Background(), &ListAlertSettingsRequest{Module: &module, Status: &status}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(settings) != 2 {
+		t.Errorf("expected 2 settings, got %d", len(settings))
+	}
+
+	invalid := AlertStatus(2)
+	_, err = c.Phone.Alerts.GetAllAlertSettings(context.Background(), &ListAlertSettingsRequest{Status: &invalid}, 0)
+	if err == nil {
+		t.Error("expected an invalid status to be rejected")
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+}
+
+func TestCreateAlertRequestTimeFrame(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{"ordered", "08:00:00", "17:30:00", false},
+		{"inverted", "17:30:00", "08:00:00", true},
+		{"empty window", "08:00:00", "08:00:00", true},
+		{"malformed", "8am", "17:30:00", true},
+		{"missing", "", "17:30:00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &CreateAlertRequest{
+				AlertSettingsName: "queue volume",
+				Module:            1,
+				Rule:              1,
+				TargetType:        1,
+				TargetIDs:         []string{"target"},
+				EmailRecipients:   []string{"noc@example.com"},
+				Frequency:         5,
+				TimeFrameType:     "specific_time",
+				TimeFrameFrom:     tt.from,
+				TimeFrameTo:       tt.to,
+			}
+			req.RuleConditions.RuleConditionType = 1
+			req.RuleConditions.RuleConditionValue = "10"
+
+			err := req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetAccountSettingsSharedVoicemailNotification(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("setting_type"); got != "shared_voicemail_notification_by_email" {
+			t.Errorf("unexpected setting_type %q", got)
+		}
+
+		w.Write([]byte(`{
+			"shared_voicemail_notification_by_email": {
+				"enable": true,
+				"locked": true,
+				"locked_by": "account",
+				"include_voicemail_file": true,
+				"include_voicemail_transcription": false
+			}
+		}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	out, _, err := c.Phone.Accounts.GetAccountSettings(context.Background(), NewAccountSettingsQuery("shared_voicemail_notification_by_email"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.SharedVoicemailNotificationByEmail
+	if !got.Enable || got.LockedBy != LockedByAccount || !got.IncludeVoicemailFile || got.IncludeVoicemailTranscription {
+		t.Errorf("unexpected settings %+v", got)
+	}
+}
+
+func TestImportAlerts(t *testing.T) {
+	var lock sync.Mutex
+	var calls []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		lock.Unlock()
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"alert_settings": [
+				{"alert_setting_id": "1", "alert_setting_name": "queue volume", "module": 1, "rule": 1},
+				{"alert_setting_id": "2", "alert_setting_name": "device offline", "module": 3, "rule": 10}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	alert := func(name string, module int) *CreateAlertRequest {
+		req := &CreateAlertRequest{
+			AlertSettingsName: name,
+			Module:            module,
+			Rule:              1,
+			TargetType:        1,
+			TargetIDs:         []string{"target"},
+			EmailRecipients:   []string{"noc@example.com"},
+			Frequency:         5,
+			TimeFrameType:     "all_day",
+		}
+		req.RuleConditions.RuleConditionType = 1
+		req.RuleConditions.RuleConditionValue = "10"
+
+		return req
+	}
+
+	result, err := c.Phone.Alerts.ImportAlerts(context.Background(), []*CreateAlertRequest{
+		alert("queue volume", 1),
+		alert("device offline", 1),
+		alert("call quality", 2),
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors["device offline"] == nil {
+		t.Errorf("expected only device offline to fail, got %v", err)
+	}
+	if fmt.Sprint(result.Updated) != "[queue volume]" || fmt.Sprint(result.Created) != "[call quality]" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	want := "[GET /phone/alert_settings PATCH /phone/alert_settings/1 POST /phone/alert_settings]"
+	if fmt.Sprint(calls) != want {
+		t.Errorf("expected calls %s, got %v", want, calls)
+	}
+}
+
+func TestAccountSettingsResponseTags(t *testing.T) {
+	var out AccountSettingsResponse
+	err := json.Unmarshal([]byte(`{
+		"personal_audio_library": {"enable": true, "allow_music_on_hold_customization": true},
+		"restricted_call_hours": {"enable": false, "locked": true}
+	}`), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.PersonalAudioLibrary.Enable || !out.PersonalAudioLibrary.AllowMusicOnHoldCustomization {
+		t.Errorf("personal_audio_library decoded into the wrong field: %+v", out.PersonalAudioLibrary)
+	}
+	if out.RestrictedCallHours.Enable || !out.RestrictedCallHours.Locked {
+		t.Errorf("restricted_call_hours decoded into the wrong field: %+v", out.RestrictedCallHours)
+	}
+}
+
+func TestListPortingNumbers(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pending_numbers") != "true" {
+			t.Errorf("expected pending numbers to be requested, got %q", r.URL.RawQuery)
+		}
+
+		w.Write([]byte(`{"phone_numbers": [
+			{"number": "+15550100", "status": "pending", "carrier": {"code": 1, "name": "Old Carrier"}},
+			{"number": "+15550101", "status": "available"}
+		]}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	numbers, err := c.Phone.Numbers.ListPortingNumbers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(numbers) != 1 || numbers[0].Number != "+15550100" || numbers[0].Carrier.Name != "Old Carrier" {
+		t.Errorf("expected only the pending number, got %+v", numbers)
+	}
+}
+
+func TestExportImportAlertsRoundTrip(t *testing.T) {
+	var lock sync.Mutex
+	var patches []map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/phone/alert_settings":
+			w.Write([]byte(`{"alert_settings": [{"alert_setting_id": "1", "alert_setting_name": "queue volume", "module": 1, "rule": 1}]}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{
+				"alert_setting_id": "1",
+				"alert_setting_name": "queue volume",
+				"module": 1,
+				"rule": 1,
+				"rule_conditions": {"rule_condition_type": 1, "rule_condition_value": "10"},
+				"target_type": 1,
+				"target_ids": ["queue"],
+				"email_recipients": ["noc@example.com"],
+				"frequency": 15,
+				"time_frame_type": "specific_time",
+				"time_frame_from": "08:00:00",
+				"time_frame_to": "17:00:00",
+				"status": 1
+			}`))
+		case r.Method == http.MethodPatch:
+			var body map[string]any
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Error(err)
+			}
+
+			lock.Lock()
+			patches = append(patches, body)
+			lock.Unlock()
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	c := newTestClient(t, handler)
+
+	exported, err := c.Phone.Alerts.ExportAlerts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := make([]*CreateAlertRequest, 0, len(exported))
+	for _, e := range exported {
+		alerts = append(alerts, e.CreateAlertRequest())
+	}
+
+	result, err := c.Phone.Alerts.ImportAlerts(context.Background(), alerts)
+	if err != nil {
+		t.Fatalf("expected the exported alerts to import, got %v", err)
+	}
+	if fmt.Sprint(result.Updated) != "[queue volume]" {
+		t.Fatalf("expected the alert to be updated, got %+v", result)
+	}
+
+	alerts[0].EmailRecipients = nil
+	alerts[0].ChatChannels = append(alerts[0].ChatChannels, struct {
+		ChatChannelName string `json:"chat_channel_name"`
+		EndPoint        string `json:"endpoint"`
+		Token           string `json:"token"`
+	}{ChatChannelName: "noc", EndPoint: "https://example.com/hook", Token: "token"})
+
+	_, err = c.Phone.Alerts.ImportAlerts(context.Background(), alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(patches) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(patches))
+	}
+	if fmt.Sprint(patches[0]["email_recipients"]) != "[noc@example.com]" || fmt.Sprint(patches[0]["target_ids"]) != "[queue]" {
+		t.Errorf("expected the exported fields to be sent, got %v", patches[0])
+	}
+	if recipients, ok := patches[1]["email_recipients"].([]any); !ok || len(recipients) != 0 {
+		t.Errorf("expected dropped recipients to be cleared, got %v", patches[1]["email_recipients"])
+	}
+}
+
+func TestImportAlertsWithoutRuleConditions(t *testing.T) {
+	var patch map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"alert_settings": [{"alert_setting_id": "1", "alert_setting_name": "device offline", "module": 3, "rule": 10}]}`))
+		case http.MethodPatch:
+			err := json.NewDecoder(r.Body).Decode(&patch)
+			if err != nil {
+				t.Error(err)
+			}
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	c := newTestClient(t, handler)
+
+	result, err := c.Phone.Alerts.ImportAlerts(context.Background(), []*CreateAlertRequest{{
+		AlertSettingsName: "device offline",
+		Module:            3,
+		Rule:              10,
+		TargetType:        1,
+		TargetIDs:         []string{"site"},
+		EmailRecipients:   []string{"noc@example.com"},
+		Frequency:         5,
+		TimeFrameType:     "all_day",
+	}})
+	if err != nil {
+		t.Fatalf("expected an alert without rule conditions to import, got %v", err)
+	}
+	if fmt.Sprint(result.Updated) != "[device offline]" {
+		t.Errorf("expected the alert to be updated, got %+v", result)
+	}
+	if _, ok := patch["rule_conditions"]; ok {
+		t.Errorf("expected no rule conditions to be sent, got %v", patch["rule_conditions"])
+	}
+}
+
+func TestUpdateAlertSettingsRequestValidate(t *testing.T) {
+	none := []string{}
+	req := &UpdateAlertSettingsRequest{Frequency: 7, EmailRecipients: &none, TimeFrameType: "specific_time", TimeFrameFrom: "17:00:00", TimeFrameTo: "08:00:00"}
+	req.ChatChannels = &[]struct {
+		ChatChannelName string `json:"chat_channel_name"`
+		EndPoint        string `json:"endpoint"`
+		Token           string `json:"token"`
+	}{}
+
+	err := req.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"frequency", "email recipient", "time_frame_from"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to mention %s, got %v", want, err)
+		}
+	}
+
+	if err := (&UpdateAlertSettingsRequest{AlertSettingsName: "renamed"}).Validate(); err != nil {
+		t.Errorf("expected a partial update to be valid, got %v", err)
+	}
+}
+
+func TestCreateAlertRequestWithoutRuleConditions(t *testing.T) {
+	req := &CreateAlertRequest{
+		AlertSettingsName: "device offline",
+		Module:            3,
+		Rule:              10,
+		TargetType:        1,
+		TargetIDs:         []string{"device"},
+		EmailRecipients:   []string{"noc@example.com"},
+		Frequency:         5,
+		TimeFrameType:     "all_day",
+	}
+
+	err := req.Validate()
+	if err != nil {
+		t.Fatalf("expected a rule without conditions to be valid, got %v", err)
+	}
+
+	b, err := MarshalRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "rule_conditions") || !strings.Contains(string(b), `"alert_settings_name":"device offline"`) {
+		t.Errorf("expected unset rule_conditions to be left out, got %s", b)
+	}
+
+	req.RuleConditions.RuleConditionValue = "10"
+	if err := req.Validate(); err == nil {
+		t.Error("expected a condition value without a type to be rejected")
+	}
+
+	req.RuleConditions.RuleConditionType = 1
+	b, err = MarshalRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"rule_conditions":{"rule_condition_type":1,"rule_condition_value":"10"}`) {
+		t.Errorf("expected rule_conditions to be sent, got %s", b)
+	}
+}
+
+func TestGetPlanInformation(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/phone/plans" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"plan_base": {"deduction_method": "metered", "callout_countries": [{"code": "US", "name": "United States"}]},
+			"plan_calling": [{"type": 200, "name": "Pro", "subscribed": 50, "assigned": 40, "available": 10, "billing_account_id": "ba", "start_date": "2024-01-01", "end_date": ""}],
+			"plan_number": [{"type": 10, "name": "Numbers", "subscribed": 20, "start_date": "2024-01-01T00:00:00Z"}]
+		}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	out, _, err := c.Phone.Plans.GetPlanInformation(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calling := out.PlanCalling[0]
+	if calling.Type != 200 || calling.Subscribed != 50 || calling.BillingAccountID != "ba" {
+		t.Errorf("unexpected calling plan %+v", calling)
+	}
+	if !calling.StartDate.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !calling.EndDate.IsZero() {
+		t.Errorf("unexpected dates %s to %s", calling.StartDate, calling.EndDate)
+	}
+	if !out.PlanNumber[0].StartDate.Equal(calling.StartDate.Time) || out.PlanBase.CalloutCountries[0].Code != "US" {
+		t.Errorf("unexpected plan information %+v", out)
+	}
+}
+
+func TestAddCustomizedNumbersFromReader(t *testing.T) {
+	ids := func(prefix string, n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "%s%d\n", prefix, i)
+		}
+
+		return b.String()
+	}
+
+	tests := []struct {
+		name       string
+		csv        string
+		wantChunks []int
+		wantAdded  int
+		wantFailed map[int]string
+	}{
+		{
+			name:       "blank lines",
+			csv:        "a1\n\n  \nb2,extra column\n",
+			wantChunks: []int{2},
+			wantAdded:  2,
+			wantFailed: map[int]string{},
+		},
+		{
+			name:       "duplicate and invalid ids",
+			csv:        "a1\na1\nnot valid\nb/2\nc3\n",
+			wantChunks: []int{2},
+			wantAdded:  2,
+			wantFailed: map[int]string{2: "duplicate", 3: "invalid", 4: "invalid"},
+		},
+		{
+			name:       "more than 30 ids",
+			csv:        ids("id", 65),
+			wantChunks: []int{30, 30, 5},
+			wantAdded:  65,
+			wantFailed: map[int]string{},
+		},
+		{
+			name:       "rejected chunk",
+			csv:        ids("id", 30) + "reject\n\nlast\n",
+			wantChunks: []int{30, 2},
+			wantAdded:  30,
+			wantFailed: map[int]string{31: "rejected", 33: "rejected"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks []int
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req AddCustomizedNumbersRequest
+				err := json.NewDecoder(r.Body).Decode(&req)
+				if err != nil {
+					t.Error(err)
+				}
+				chunks = append(chunks, len(req.PhoneNumberIDs))
+
+				if slices.Contains(req.PhoneNumberIDs, "reject") {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"code": 300, "message": "rejected"}`))
+					return
+				}
+
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			c := newTestClient(t, handler)
+
+			result, err := c.Phone.Accounts.AddCustomizedNumbersFromReader(context.Background(), strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if fmt.Sprint(chunks) != fmt.Sprint(tt.wantChunks) {
+				t.Errorf("expected chunks of %v, got %v", tt.wantChunks, chunks)
+			}
+			if len(result.Added) != tt.wantAdded {
+				t.Errorf("expected %d ids added, got %v", tt.wantAdded, result.Added)
+			}
+
+			failed := map[int]string{}
+			for _, f := range result.Failed {
+				failed[f.Line] = f.Error()
+			}
+			if len(failed) != len(tt.wantFailed) {
+				t.Errorf("expected failed lines %v, got %v", tt.wantFailed, failed)
+			}
+			for line, want := range tt.wantFailed {
+				if !strings.Contains(failed[line], want) {
+					t.Errorf("line %d: expected an error mentioning %q, got %q", line, want, failed[line])
+				}
+			}
+		})
+	}
+}
+
+func TestAlertSettingByName(t *testing.T) {
+	var lock sync.Mutex
+	var posts int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/phone/alert_settings" && r.URL.Query().Get("next_page_token") == "":
+			w.Write([]byte(`{"next_page_token": "next", "alert_settings": [
+				{"alert_setting_id": "1", "alert_setting_name": "queue volume"},
+				{"alert_setting_id": "2", "alert_setting_name": "duplicate"}
+			]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/phone/alert_settings":
+			w.Write([]byte(`{"alert_settings": [
+				{"alert_setting_id": "3", "alert_setting_name": "duplicate"},
+				{"alert_setting_id": "4", "alert_setting_name": "device offline"}
+			]}`))
+		case r.Method == http.MethodGet:
+			id := strings.TrimPrefix(r.URL.Path, "/phone/alert_settings/")
+			fmt.Fprintf(w, `{"alert_setting_id": %q, "alert_setting_name": "device offline"}`, id)
+		case r.Method == http.MethodPost:
+			lock.Lock()
+			posts++
+			lock.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"alert_setting_id": "5", "alert_setting_name": "call quality"}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	c := newTestClient(t, handler)
+	ctx := context.Background()
+
+	alert := func(name string) *CreateAlertRequest {
+		return &CreateAlertRequest{
+			AlertSettingsName: name,
+			Module:            1,
+			Rule:              1,
+			TargetType:        1,
+			TargetIDs:         []string{"target"},
+			EmailRecipients:   []string{"noc@example.com"},
+			Frequency:         5,
+			TimeFrameType:     "all_day",
+		}
+	}
+
+	t.Run("found on a later page", func(t *testing.T) {
+		got, err := c.Phone.Alerts.GetAlertSettingByName(ctx, "device offline")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.AlertSettingID != "4" {
+			t.Errorf("expected alert setting 4, got %+v", got)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.Phone.Alerts.GetAlertSettingByName(ctx, "missing")
+		if !errors.Is(err, ErrAlertSettingNotFound) {
+			t.Errorf("expected ErrAlertSettingNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		_, err := c.Phone.Alerts.GetAlertSettingByName(ctx, "duplicate")
+		if !errors.Is(err, ErrAlertSettingAmbiguous) {
+			t.Errorf("expected ErrAlertSettingAmbiguous, got %v", err)
+		}
+
+		_, _, err = c.Phone.Alerts.CreateAlertIfAbsent(ctx, alert("duplicate"))
+		if !errors.Is(err, ErrAlertSettingAmbiguous) || posts != 0 {
+			t.Errorf("expected nothing to be created for an ambiguous name, got %v after %d creates", err, posts)
+		}
+	})
+
+	t.Run("skips existing", func(t *testing.T) {
+		out, created, err := c.Phone.Alerts.CreateAlertIfAbsent(ctx, alert("device offline"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created || out.AlertSettingID != "4" || posts != 0 {
+			t.Errorf("expected the existing alert to be returned, got %+v, created %v after %d creates", out, created, posts)
+		}
+	})
+
+	t.Run("creates absent", func(t *testing.T) {
+		out, created, err := c.Phone.Alerts.CreateAlertIfAbsent(ctx, alert("call quality"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !created || out.AlertSettingID != "5" || posts != 1 {
+			t.Errorf("expected the alert to be created, got %+v, created %v after %d creates", out, created, posts)
+		}
+	})
+}
+
+func TestGetExtensionIDByNumber(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") != "all" {
+			t.Errorf("expected every number to be searched, got %q", r.URL.RawQuery)
+		}
+
+		if r.URL.Query().Get("next_page_token") == "" {
+			w.Write([]byte(`{"next_page_token": "next", "phone_numbers": [
+				{"number": "+155501001", "assignee": {"id": "other", "extension_number": 101}},
+				{"number": "+15550200"}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{"phone_numbers": [
+			{"number": "+15550100", "assignee": {"id": "ext", "extension_number": 100, "type": "user"}}
+		]}`))
+	})
+
+	c := newTestClient(t, handler)
+	ctx := context.Background()
+
+	assignee, err := c.Phone.Numbers.GetExtensionIDByNumber(ctx, "+15550100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignee.ID != "ext" || assignee.ExtensionNumber != "100" {
+		t.Errorf("expected the exact match on the second page, got %+v", assignee)
+	}
+
+	_, err = c.Phone.Numbers.GetExtensionIDByNumber(ctx, "+15550200")
+	if !errors.Is(err, ErrPhoneNumberUnassigned) {
+		t.Errorf("expected ErrPhoneNumberUnassigned, got %v", err)
+	}
+
+	_, err = c.Phone.Numbers.GetExtensionIDByNumber(ctx, "+15550300")
+	if !errors.Is(err, ErrPhoneNumberNotFound) {
+		t.Errorf("expected ErrPhoneNumberNotFound, got %v", err)
+	}
+}
+
+func TestFindSharedLineGroupByNumber(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/phone/shared_line_groups" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("next_page_token") == "" {
+			w.Write([]byte(`{"next_page_token": "next", "shared_line_groups": [
+				{"id": "front", "phone_numbers": [{"id": "1", "number": "+155501001"}]}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{"shared_line_groups": [
+			{"id": "empty"},
+			{"id": "support", "phone_numbers": [{"id": "2", "number": "+15550200"}, {"id": "3", "number": "+15550100"}]}
+		]}`))
+	})
+
+	c := newTestClient(t, handler)
+	ctx := context.Background()
+
+	group, err := c.Phone.SharedLineGroups.FindSharedLineGroupByNumber(ctx, "+15550100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.ID != "support" {
+		t.Errorf("expected the support group, got %+v", group)
+	}
+
+	_, err = c.Phone.SharedLineGroups.FindSharedLineGroupByNumber(ctx, "+15550300")
+	if !errors.Is(err, ErrSharedLineGroupNotFound) {
+		t.Errorf("expected ErrSharedLineGroupNotFound, got %v", err)
+	}
+}
+
+func TestListOperationLogsByOperator(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") != "2024-05-01" || r.URL.Query().Get("category_type") != "user" {
+			t.Errorf("expected the request filters to be sent, got %q", r.URL.RawQuery)
+		}
+
+		if r.URL.Query().Get("next_page_token") == "" {
+			w.Write([]byte(`{"next_page_token": "next", "operation_logs": [
+				{"action": "Add", "operator": "admin@example.com"},
+				{"action": "Delete", "operator": "other@example.com"}
+			]}`))
+			return
+		}
+
+		w.Write([]byte(`{"operation_logs": [
+			{"action": "Update", "operator": "Admin@Example.com"}
+		]}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	logs, err := c.Phone.Reports.ListOperationLogsByOperator(context.Background(), &ListOperationLogsRequest{From: "2024-05-01", To: "2024-05-31", CategoryType: "user"}, "admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actions []string
+	for _, log := range logs {
+		actions = append(actions, log.Action)
+	}
+	if fmt.Sprint(actions) != "[Add Update]" {
+		t.Errorf("expected the operator's logs from every page, got %v", actions)
+	}
+}
+
+func TestGetCallingPlanUsage(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/phone/calling_plans" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		w.Write([]byte(`{"calling_plans": [
+			{"type": 200, "name": "US/CA Unlimited", "subscribed": 10, "assigned": 8, "available": 2, "billing_account_id": "a"},
+			{"type": 100, "name": "Metered", "subscribed": 5, "assigned": 1, "available": 4},
+			{"type": 200, "name": "US/CA Unlimited", "subscribed": 20, "assigned": 5, "available": 15, "billing_account_id": "b"}
+		]}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	usage, err := c.Phone.Plans.GetCallingPlanUsage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []CallingPlanUsage{
+		{Type: 200, Name: "US/CA Unlimited", Total: 30, Assigned: 13, Available: 17},
+		{Type: 100, Name: "Metered", Total: 5, Assigned: 1, Available: 4},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("expected %d plan types, got %d", len(want), len(usage))
+	}
+	for i, w := range want {
+		if *usage[i] != w {
+			t.Errorf("plan %d: expected %+v, got %+v", i, w, *usage[i])
+		}
+	}
+}
+
+func TestListAccountRecordingsDateRange(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"recordings": []}`))
+	})
+
+	c := newTestClient(t, handler)
+
+	tests := []struct {
+		from, to string
+		wantErr  string
+	}{
+		{"2024-05-01", "2024-05-31", ""},
+		{"2024-05-01", "2024-05-01", ""},
+		{"2024-05-31", "2024-05-01", "after"},
+		{"05/01/2024", "2024-05-31", "invalid from date"},
+		{"2024-05-01", "2024-05-32", "invalid to date"},
+	}
+
+	for _, tt := range tests {
+		calls.Store(0)
+
+		_, _, err := c.Phone.Recordings.ListAccountRecordings(context.Background(), &ListAccountRecordingsRequest{From: tt.from, To: tt.to})
+		if len(tt.wantErr) == 0 {
+			if err != nil {
+				t.Errorf("%s to %s: expected no error, got %v", tt.from, tt.to, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s to %s: expected an error mentioning %q, got %v", tt.from, tt.to, tt.wantErr, err)
+		}
+		if calls.Load() != 0 {
+			t.Errorf("%s to %s: expected no request to be made", tt.from, tt.to)
+		}
+	}
+}
+
+func TestAllSettingTypes(t *testing.T) {
+	types := AllSettingTypes()
+	if len(types) != len(availableSettingTypes) || !slices.Contains(types, "shared_voicemail_notification_by_email") {
+		t.Fatalf("expected every setting type, got %v", types)
+	}
+
+	seen := map[SettingType]bool{}
+	for _, st := range types {
+		if seen[st] {
+			t.Errorf("duplicate setting type %s", st)
+		}
+		seen[st] = true
+	}
+
+	types[0] = "modified"
+	if AllSettingTypes()[0] == "modified" {
+		t.Error("expected a copy of the setting types")
+	}
+}
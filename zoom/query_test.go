@@ -0,0 +1,57 @@
+package zoom
+
+import (
+	"reflect"
+	"testing"
+
+	querystring "github.com/google/go-querystring/query"
+)
+
+func TestQueryEncodingEmbeddedPagination(t *testing.T) {
+	token := "next"
+	size := 50
+	pagination := &PaginationOptions{NextPageToken: &token, PageSize: &size}
+
+	tests := []struct {
+		name      string
+		empty     any
+		populated any
+	}{
+		{"GetCustomizedNumbersRequest", &GetCustomizedNumbersRequest{}, &GetCustomizedNumbersRequest{PaginationOptions: pagination}},
+		{"ListPhoneNumbersRequest", &ListPhoneNumbersRequest{}, &ListPhoneNumbersRequest{PaginationOptions: pagination}},
+		{"ListAlertSettingsRequest", &ListAlertSettingsRequest{}, &ListAlertSettingsRequest{PaginationOptions: pagination}},
+		{"ListAccountRecordingsRequest", &ListAccountRecordingsRequest{}, &ListAccountRecordingsRequest{PaginationOptions: pagination}},
+		{"ListOperationLogsRequest", &ListOperationLogsRequest{}, &ListOperationLogsRequest{PaginationOptions: pagination}},
+		{"ListSharedLineGroupsRequest", &ListSharedLineGroupsRequest{}, &ListSharedLineGroupsRequest{PaginationOptions: pagination}},
+		{"UsersListOptions", &UsersListOptions{}, &UsersListOptions{PaginationOptions: pagination}},
+		{"MeetingsListOptions", &MeetingsListOptions{}, &MeetingsListOptions{PaginationOptions: pagination}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := querystring.Values(reflect.Zero(reflect.TypeOf(tt.empty)).Interface())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(q) != 0 {
+				t.Fatalf("expected no parameters for a nil request, got %q", q.Encode())
+			}
+
+			q, err = querystring.Values(tt.empty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(q) != 0 {
+				t.Fatalf("expected no parameters, got %q", q.Encode())
+			}
+
+			q, err = querystring.Values(tt.populated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := q.Encode(); got != "next_page_token=next&page_size=50" {
+				t.Fatalf("expected flattened pagination parameters, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package zoom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrDailyRateLimitExceeded matches a *DailyRateLimitError with errors.Is.
+var ErrDailyRateLimitExceeded = errors.New("daily rate limit exceeded")
+
+// DailyRateLimitError is returned when the account has used up its daily
+// quota for an API. Unlike the per-second limits, it is not retried: the
+// caller should stop and resume after ResetAt.
+type DailyRateLimitError struct {
+	// ResetAt is when the quota resets, taken from the Retry-After header
+	// as delta-seconds, an HTTP-date or an RFC 3339 timestamp. It is zero if
+	// Zoom did not send one that parses.
+	ResetAt time.Time
+	// Response is the API error Zoom returned.
+	Response *ErrorResponse
+}
+
+func (e *DailyRateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return fmt.Sprintf("%v: %v", ErrDailyRateLimitExceeded, e.Response)
+	}
+
+	return fmt.Sprintf("%v until %s: %v", ErrDailyRateLimitExceeded, e.ResetAt.Format(time.RFC3339), e.Response)
+}
+
+func (e *DailyRateLimitError) Is(target error) bool {
+	return target == ErrDailyRateLimitExceeded
+}
+
+func (e *DailyRateLimitError) Unwrap() error {
+	return e.Response
+}
+
+// dailyRateLimited reports whether res is a 429 for the daily quota rather
+// than a per-second limit.
+func dailyRateLimited(res *http.Response) bool {
+	return res.StatusCode == http.StatusTooManyRequests && strings.Contains(strings.ToLower(res.Header.Get("X-RateLimit-Type")), "daily")
+}
+
+func newDailyRateLimitError(res *http.Response, errRes *ErrorResponse, now time.Time) *DailyRateLimitError {
+	e := &DailyRateLimitError{Response: errRes}
+
+	value := strings.TrimSpace(res.Header.Get("Retry-After"))
+
+	// Besides the standard forms, Zoom may send the reset time of the daily
+	// quota as an RFC 3339 timestamp.
+	resetAt, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		e.ResetAt = resetAt
+		return e
+	}
+
+	delay, ok := parseRetryAfter(value, now)
+	if ok {
+		e.ResetAt = now.Add(delay)
+	}
+
+	return e
+}
@@ -0,0 +1,183 @@
+package zoom
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+
+	defaultMaxRetryAfter = 5 * time.Minute
+)
+
+// RetryPolicy controls how the client retries requests that fail with a
+// transient status code.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the initial attempt.
+	MaxRetries int
+	// StatusCodes are the response status codes retried for every request.
+	StatusCodes []int
+	// MinBackoff is the delay before the first retry. It doubles on every
+	// subsequent retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Jitter randomizes each backoff delay to spread out retries from many
+	// clients. Nil disables jitter.
+	Jitter func(delay time.Duration) time.Duration
+	// MaxRetryAfter caps the delay requested by a Retry-After response
+	// header, which is used instead of the backoff when present. Zero means
+	// five minutes.
+	MaxRetryAfter time.Duration
+}
+
+// FullJitter returns a Jitter function picking a delay uniformly between zero
+// and the backoff delay, drawing from src. Pass a fixed source to get
+// deterministic delays in tests.
+func FullJitter(src rand.Source) func(time.Duration) time.Duration {
+	var lock sync.Mutex
+	r := rand.New(src)
+
+	return func(delay time.Duration) time.Duration {
+		if delay <= 0 {
+			return 0
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		return time.Duration(r.Int63n(int64(delay) + 1))
+	}
+}
+
+// DefaultRetryPolicy does not retry any status code on its own, but allows
+// per-call codes added with WithRetryOn to be retried.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+		MinBackoff: defaultMinBackoff,
+		MaxBackoff: defaultMaxBackoff,
+		Jitter:     FullJitter(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// WithRetryPolicy sets the client-level retry policy.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy != nil {
+			c.retryPolicy = policy
+		}
+	}
+}
+
+type retryOnKey struct{}
+
+// WithRetryOn returns a context that retries the request made with it on the
+// given status codes, in addition to those of the client-level policy.
+func WithRetryOn(ctx context.Context, codes ...int) context.Context {
+	existing, _ := ctx.Value(retryOnKey{}).([]int)
+
+	return context.WithValue(ctx, retryOnKey{}, append(slices.Clone(existing), codes...))
+}
+
+func retryOnCodes(ctx context.Context) []int {
+	codes, _ := ctx.Value(retryOnKey{}).([]int)
+	return codes
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, statusCode int) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+
+	return slices.Contains(p.StatusCodes, statusCode) || slices.Contains(retryOnCodes(ctx), statusCode)
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 0; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, p.MaxBackoff)
+	if p.Jitter != nil {
+		delay = p.Jitter(delay)
+	}
+
+	return delay
+}
+
+// retryDelay returns how long to wait before retrying the request that got
+// res: the server's Retry-After, capped to MaxRetryAfter, or the backoff if it
+// sent none that parses.
+func (p *RetryPolicy) retryDelay(attempt int, res *http.Response, now time.Time) time.Duration {
+	delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), now)
+	if !ok {
+		return p.backoff(attempt)
+	}
+
+	maxDelay := p.MaxRetryAfter
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryAfter
+	}
+
+	return min(delay, maxDelay)
+}
+
+// parseRetryAfter parses a Retry-After header value, either delta-seconds or
+// an HTTP-date, into a delay from now. Dates in the past give a zero delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return math.MaxInt64, true
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// fitsDeadline reports whether delay elapses before the context deadline, if
+// any.
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,196 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, w := range want {
+		if got := p.backoff(attempt); got != w {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, w, got)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffSeededJitter(t *testing.T) {
+	p := &RetryPolicy{
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Second,
+		Jitter:     FullJitter(rand.NewSource(1)),
+	}
+
+	want := []time.Duration{370772624, 744820942, 2133774669, 3427569893, 503796014}
+	for attempt, w := range want {
+		got := p.backoff(attempt)
+		if got != w {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, w, got)
+		}
+		if got > p.MaxBackoff {
+			t.Errorf("attempt %d: %s exceeds max backoff", attempt, got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"soon", 0, false},
+		{"99999999999999999", math.MaxInt64, true},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 May 2024 11:59:00 GMT", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%q: expected (%s, %v), got (%s, %v)", tt.value, tt.want, tt.wantOK, got, ok)
+		}
+	}
+}
+
+func TestRetryPolicyRetryDelay(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second, MaxRetryAfter: time.Minute}
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{"absent", "", 2 * time.Second},
+		{"unparseable", "later", 2 * time.Second},
+		{"seconds", "10", 10 * time.Second},
+		{"date", "Wed, 01 May 2024 12:00:20 GMT", 20 * time.Second},
+		{"clamped", "86400", time.Minute},
+	}
+
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{}}
+		if len(tt.retryAfter) > 0 {
+			res.Header.Set("Retry-After", tt.retryAfter)
+		}
+
+		if got := p.retryDelay(1, res, now); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+
+	if got := (&RetryPolicy{}).retryDelay(0, &http.Response{Header: http.Header{"Retry-After": {"86400"}}}, now); got != defaultMaxRetryAfter {
+		t.Errorf("expected the default clamp %s, got %s", defaultMaxRetryAfter, got)
+	}
+}
+
+func TestWithRetryOn(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}
+
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(int(calls.Add(1))-1, len(statuses)-1)]
+		w.WriteHeader(status)
+		w.Write([]byte(`{"code": 0, "message": "status"}`))
+	})
+
+	var lock sync.Mutex
+	var delays []time.Duration
+	policy := retryingPolicy()
+	policy.MinBackoff, policy.MaxBackoff = time.Millisecond, 10*time.Millisecond
+	policy.Jitter = func(d time.Duration) time.Duration {
+		lock.Lock()
+		defer lock.Unlock()
+
+		delays = append(delays, d)
+		return d
+	}
+
+	c := newTestClient(t, handler, WithRetryPolicy(policy))
+
+	t.Run("adds to the client policy", func(t *testing.T) {
+		calls.Store(0)
+		delays = nil
+
+		_, err := c.request(WithRetryOn(context.Background(), http.StatusTooManyRequests), http.MethodGet, "/users", nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("expected the per-call and the client policy codes to be retried, got %d calls", calls.Load())
+		}
+		if fmt.Sprint(delays) != "[1ms 2ms]" {
+			t.Errorf("expected the client policy's backoff, got %v", delays)
+		}
+	})
+
+	t.Run("per call only", func(t *testing.T) {
+		calls.Store(0)
+		delays = nil
+
+		_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+		if err == nil || calls.Load() != 1 {
+			t.Errorf("expected 429 not to be retried without WithRetryOn, got %v after %d calls", err, calls.Load())
+		}
+		if len(delays) != 0 {
+			t.Errorf("expected no backoff to be drawn, got %v", delays)
+		}
+	})
+
+	t.Run("past the deadline", func(t *testing.T) {
+		calls.Store(0)
+		policy.MinBackoff, policy.MaxBackoff = time.Second, time.Second
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := c.request(WithRetryOn(ctx, http.StatusTooManyRequests), http.MethodGet, "/users", nil, nil, nil)
+		if err == nil || errors.Is(err, context.DeadlineExceeded) || calls.Load() != 1 {
+			t.Errorf("expected the 429 to be returned without waiting, got %v after %d calls", err, calls.Load())
+		}
+	})
+}
+
+func TestRetryDelayOnlyDrawnForRetries(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	var draws atomic.Int32
+	policy := retryingPolicy()
+	policy.Jitter = func(d time.Duration) time.Duration {
+		draws.Add(1)
+		return d
+	}
+
+	c := newTestClient(t, handler, WithRetryPolicy(policy))
+
+	for i := 0; i < 3; i++ {
+		_, err := c.request(context.Background(), http.MethodGet, "/users", nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if draws.Load() != 0 {
+		t.Errorf("expected successful requests not to draw jitter, got %d draws", draws.Load())
+	}
+}
@@ -0,0 +1,84 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrStreamIdle is returned when a streamed response stops making progress for
+// longer than the stream idle timeout.
+var ErrStreamIdle = errors.New("stream idle timeout exceeded")
+
+// WithStreamIdleTimeout aborts requests that stream their response into an
+// io.Writer, such as recording downloads, once no data has arrived for d.
+// Such requests are then exempt from the default timeout, so a slow download
+// that keeps making progress is not cut short. d <= 0 disables it, which is
+// the default.
+func WithStreamIdleTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.streamIdleTimeout = d
+	}
+}
+
+// idleWatchdog cancels a context once it has not been kicked for its timeout.
+// A nil watchdog does nothing.
+type idleWatchdog struct {
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+// withIdleWatchdog returns a context cancelled with ErrStreamIdle unless the
+// returned watchdog is kicked at least every timeout.
+func withIdleWatchdog(ctx context.Context, timeout time.Duration) (context.Context, *idleWatchdog, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	w := &idleWatchdog{
+		timer:   time.AfterFunc(timeout, func() { cancel(ErrStreamIdle) }),
+		timeout: timeout,
+	}
+
+	return ctx, w, func() {
+		w.timer.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// pause stops the watchdog until it is kicked again, e.g. while a request
+// waits to be retried.
+func (w *idleWatchdog) pause() {
+	if w != nil {
+		w.timer.Stop()
+	}
+}
+
+func (w *idleWatchdog) kick() {
+	if w != nil {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+// idleCause returns ErrStreamIdle if ctx was cancelled by an idle watchdog,
+// and err otherwise.
+func idleCause(ctx context.Context, err error) error {
+	if errors.Is(context.Cause(ctx), ErrStreamIdle) {
+		return ErrStreamIdle
+	}
+
+	return err
+}
+
+// idleReader kicks its watchdog whenever a read returns data.
+type idleReader struct {
+	r        io.Reader
+	watchdog *idleWatchdog
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.watchdog.kick()
+	}
+
+	return n, err
+}
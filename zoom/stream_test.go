@@ -0,0 +1,101 @@
+package zoom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// throttledHandler writes chunks bytes, waiting interval before each one, and
+// then stalls for stall before returning.
+func throttledHandler(chunks int, interval, stall time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < chunks; i++ {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(interval):
+			}
+
+			w.Write([]byte("x"))
+			w.(http.Flusher).Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(stall):
+		}
+	})
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	opts := []ClientOption{
+		WithDefaultTimeout(50 * time.Millisecond),
+		WithStreamIdleTimeout(100 * time.Millisecond),
+	}
+
+	t.Run("slow but progressing", func(t *testing.T) {
+		c := newTestClient(t, throttledHandler(10, 20*time.Millisecond, 0), opts...)
+
+		var buf bytes.Buffer
+		_, err := c.Phone.Recordings.DownloadRecording(context.Background(), "id", &buf)
+		if err != nil {
+			t.Fatalf("expected the download to outlast the default timeout, got %v", err)
+		}
+		if buf.Len() != 10 {
+			t.Errorf("expected 10 bytes, got %d", buf.Len())
+		}
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		c := newTestClient(t, throttledHandler(1, 0, time.Second), opts...)
+
+		var buf bytes.Buffer
+		_, err := c.Phone.Recordings.DownloadRecording(context.Background(), "id", &buf)
+		if !errors.Is(err, ErrStreamIdle) {
+			t.Fatalf("expected ErrStreamIdle, got %v", err)
+		}
+	})
+
+	t.Run("caller deadline", func(t *testing.T) {
+		c := newTestClient(t, throttledHandler(10, 20*time.Millisecond, 0), opts...)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var buf bytes.Buffer
+		_, err := c.Phone.Recordings.DownloadRecording(ctx, "id", &buf)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the caller's deadline to apply, got %v", err)
+		}
+	})
+}
+
+func TestStreamIdleTimeoutPausedDuringBackoff(t *testing.T) {
+	var calls atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code": 429, "message": "Too many requests."}`))
+			return
+		}
+
+		w.Write([]byte("audio"))
+	})
+
+	c := newTestClient(t, handler, WithStreamIdleTimeout(200*time.Millisecond))
+
+	var buf bytes.Buffer
+	_, err := c.Phone.Recordings.DownloadRecording(WithRetryOn(context.Background(), http.StatusTooManyRequests), "id", &buf)
+	if err != nil {
+		t.Fatalf("expected the backoff not to count as idle time, got %v", err)
+	}
+	if buf.String() != "audio" || calls.Load() != 2 {
+		t.Errorf("expected the retried download, got %q after %d calls", buf.String(), calls.Load())
+	}
+}
@@ -0,0 +1,94 @@
+package zoom
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// TransportConfig tunes the connection pool of the transport the client
+// creates when NewClient is given a nil *http.Client. It has no effect on a
+// caller-supplied client.
+type TransportConfig struct {
+	// MaxIdleConns caps idle connections across all hosts. Defaults to 100.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps idle connections kept to the Zoom API. Since
+	// the client talks to a single host this defaults to MaxIdleConns.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps all connections to the Zoom API, including ones in
+	// use. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections idle for longer than this. Defaults
+	// to 90 seconds.
+	IdleConnTimeout time.Duration
+
+	proxy   *url.URL
+	rootCAs *x509.CertPool
+}
+
+func defaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// WithTransportConfig sets the connection pool settings of the default
+// transport.
+func WithTransportConfig(cfg TransportConfig) ClientOption {
+	return func(c *Client) {
+		cfg.proxy = c.transportConfig.proxy
+		cfg.rootCAs = c.transportConfig.rootCAs
+		c.transportConfig = cfg
+	}
+}
+
+// WithProxy sends requests from the default transport through the proxy at
+// proxyURL, which must be an absolute http, https or socks5 URL. It has no
+// effect on a caller-supplied *http.Client.
+func WithProxy(proxyURL string) (ClientOption, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing proxy URL: %w", err)
+	}
+
+	if !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) || len(u.Host) == 0 {
+		return nil, fmt.Errorf("Error: invalid proxy URL '%s'", proxyURL)
+	}
+
+	return func(c *Client) {
+		c.transportConfig.proxy = u
+	}, nil
+}
+
+// WithRootCAs makes the default transport verify servers against pool
+// instead of the system roots, e.g. when a proxy re-signs TLS traffic with an
+// internal CA. It has no effect on a caller-supplied *http.Client.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		c.transportConfig.rootCAs = pool
+	}
+}
+
+func (cfg TransportConfig) transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = cfg.MaxIdleConns
+	t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	t.IdleConnTimeout = cfg.IdleConnTimeout
+
+	if cfg.proxy != nil {
+		t.Proxy = http.ProxyURL(cfg.proxy)
+	}
+
+	if cfg.rootCAs != nil {
+		t.TLSClientConfig = &tls.Config{RootCAs: cfg.rootCAs}
+	}
+
+	return t
+}
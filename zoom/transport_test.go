@@ -0,0 +1,81 @@
+package zoom
+
+import (
+	"crypto/x509"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithProxy(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"http://proxy.internal:3128", false},
+		{"https://proxy.internal", false},
+		{"socks5://127.0.0.1:1080", false},
+		{"", true},
+		{"proxy.internal:3128", true},
+		{"ftp://proxy.internal", true},
+		{"http://", true},
+		{"http://proxy internal", true},
+	}
+
+	for _, tt := range tests {
+		opt, err := WithProxy(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.url, tt.wantErr, err)
+		}
+		if (opt == nil) != tt.wantErr {
+			t.Errorf("%q: expected an option only for a valid URL", tt.url)
+		}
+	}
+}
+
+func TestTransportSettings(t *testing.T) {
+	proxy, err := WithProxy("http://proxy.internal:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	cfg := TransportConfig{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		MaxConnsPerHost:     20,
+		IdleConnTimeout:     time.Minute,
+	}
+
+	c := NewClient(nil, "account", "client", "secret", nil, proxy, WithRootCAs(pool), WithTransportConfig(cfg))
+
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", c.httpClient.Transport)
+	}
+
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.MaxConnsPerHost != 20 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("expected the pool settings to be applied, got %d, %d, %d and %s",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.zoom.us/v2/users", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyURL, err := transport.Proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.String() != "http://proxy.internal:3128" {
+		t.Errorf("expected the proxy to be kept after WithTransportConfig, got %v, %v", proxyURL, err)
+	}
+
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs != pool {
+		t.Error("expected the root CAs to be kept after WithTransportConfig")
+	}
+
+	custom := &http.Client{}
+	c = NewClient(custom, "account", "client", "secret", nil, proxy, WithRootCAs(pool), WithTransportConfig(cfg))
+	if c.httpClient != custom || custom.Transport != nil {
+		t.Error("expected a caller-supplied client to be left alone")
+	}
+}
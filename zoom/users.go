@@ -72,9 +72,40 @@ func (u *UsersService) List(ctx context.Context, opts *UsersListOptions) (*Users
 		return nil, res, fmt.Errorf("Error making request: %w", err)
 	}
 
+	if u.client.autoPaginating(ctx) {
+		err = fetchRemaining(ctx, out.PaginationResponse, &out.Users, u.pages(opts))
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
 	return out, res, nil
 }
 
+// GetAllUsers follows next_page_token until every user matching opts has been
+// fetched. If limit is greater than zero, at most limit users are returned.
+func (u *UsersService) GetAllUsers(ctx context.Context, opts *UsersListOptions, limit int) ([]*UsersListItem, error) {
+	return collectAll(ctx, limit, u.pages(opts))
+}
+
+func (u *UsersService) pages(opts *UsersListOptions) pageFunc[*UsersListItem] {
+	o := UsersListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	return func(ctx context.Context, cursor *PaginationOptions) ([]*UsersListItem, *PaginationResponse, error) {
+		o.PaginationOptions = withPage(o.PaginationOptions, cursor)
+
+		out, _, err := u.List(ctx, &o)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return out.Users, out.PaginationResponse, nil
+	}
+}
+
 type UsersCreateOptions struct {
 	Action   string                      `json:"action"`
 	UserInfo *UsersCreateOptionsUserInfo `json:"user_info"`